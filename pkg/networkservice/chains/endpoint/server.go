@@ -23,6 +23,7 @@ import (
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
 	"google.golang.org/grpc"
 
+	"github.com/networkservicemesh/sdk/pkg/tools/admin"
 	"github.com/networkservicemesh/sdk/pkg/tools/grpcutils"
 
 	"github.com/networkservicemesh/sdk/pkg/networkservice/common/monitor"
@@ -68,6 +69,14 @@ func NewServer(name string, authzServer networkservice.NetworkServiceServer, tok
 	return rv
 }
 
+// State - returns the state of the endpoint monitor: the active connections
+func (e *endpoint) State() interface{} {
+	if provider, ok := e.MonitorConnectionServer.(admin.StateProvider); ok {
+		return provider.State()
+	}
+	return nil
+}
+
 func (e *endpoint) Register(s *grpc.Server) {
 	grpcutils.RegisterHealthServices(s, e)
 	networkservice.RegisterNetworkServiceServer(s, e)
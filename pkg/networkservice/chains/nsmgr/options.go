@@ -0,0 +1,47 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nsmgr
+
+import (
+	"github.com/spiffe/go-spiffe/v2/bundle/x509bundle"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/discover"
+)
+
+type serverOptions struct {
+	dialOptions     []grpc.DialOption
+	discoverOptions []discover.Option
+}
+
+// Option is an option pattern for NewServerWithOptions
+type Option func(o *serverOptions)
+
+// WithDialOptions sets grpc.DialOptions to be passed to the GRPC connections
+func WithDialOptions(dialOptions ...grpc.DialOption) Option {
+	return func(o *serverOptions) {
+		o.dialOptions = append(o.dialOptions, dialOptions...)
+	}
+}
+
+// WithNetworkServicePolicies enables the per network service authorization policies published to the registry (see
+// nspolicy.Publish), the client tokens are verified by the bundle (see discover.WithNetworkServicePolicies)
+func WithNetworkServicePolicies(bundle x509bundle.Source) Option {
+	return func(o *serverOptions) {
+		o.discoverOptions = append(o.discoverOptions, discover.WithNetworkServicePolicies(bundle))
+	}
+}
@@ -21,12 +21,14 @@ package peertracker
 import (
 	"context"
 	"net/url"
+	"sort"
 
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
 	"google.golang.org/grpc/peer"
 
 	"github.com/networkservicemesh/sdk/pkg/networkservice/chains/nsmgr"
+	"github.com/networkservicemesh/sdk/pkg/tools/admin"
 	"github.com/networkservicemesh/sdk/pkg/tools/serialize"
 )
 
@@ -36,6 +38,7 @@ const (
 
 type peerTrackerServer struct {
 	nsmgr.Nsmgr
+	admin    *admin.Registry
 	executor serialize.Executor
 	// Outer map is peer url.URL.String(), inner map key is Connection.Id
 	connections map[string]map[string]*networkservice.Connection
@@ -51,9 +54,35 @@ func NewServer(inner nsmgr.Nsmgr, closeAll *func(ctx context.Context, u *url.URL
 		Nsmgr:       inner,
 	}
 	*closeAll = rv.closeAllConnectionsForPeer
+	rv.admin = admin.NewRegistry()
+	if provider, ok := inner.(nsmgr.AdminProvider); ok {
+		rv.admin = provider.Admin()
+	}
+	rv.admin.Register("peertracker", rv)
 	return rv
 }
 
+// Admin - returns admin.Registry of the wrapped Nsmgr with the peer tracker state added
+func (p *peerTrackerServer) Admin() *admin.Registry {
+	return p.admin
+}
+
+// State - returns IDs of the connections by peer url.URL
+func (p *peerTrackerServer) State() interface{} {
+	peers := make(map[string][]string)
+	<-p.executor.AsyncExec(func() {
+		for u, connMap := range p.connections {
+			ids := make([]string, 0, len(connMap))
+			for id := range connMap {
+				ids = append(ids, id)
+			}
+			sort.Strings(ids)
+			peers[u] = ids
+		}
+	})
+	return peers
+}
+
 func (p *peerTrackerServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
 	conn, err := p.Nsmgr.Request(ctx, request)
 	if err != nil {
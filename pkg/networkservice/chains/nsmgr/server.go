@@ -20,6 +20,9 @@
 package nsmgr
 
 import (
+	"context"
+	"time"
+
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
 	registryapi "github.com/networkservicemesh/api/pkg/api/registry"
 	"google.golang.org/grpc"
@@ -27,6 +30,7 @@ import (
 	"github.com/networkservicemesh/sdk/pkg/networkservice/chains/registry"
 	"github.com/networkservicemesh/sdk/pkg/tools/grpcutils"
 
+	"github.com/networkservicemesh/sdk/pkg/registry/common/refresh"
 	"github.com/networkservicemesh/sdk/pkg/registry/common/setid"
 	"github.com/networkservicemesh/sdk/pkg/registry/common/seturl"
 	chain_registry "github.com/networkservicemesh/sdk/pkg/registry/core/chain"
@@ -42,9 +46,12 @@ import (
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/adapters"
 	adapter_registry "github.com/networkservicemesh/sdk/pkg/registry/core/adapters"
 	"github.com/networkservicemesh/sdk/pkg/tools/addressof"
+	"github.com/networkservicemesh/sdk/pkg/tools/admin"
 	"github.com/networkservicemesh/sdk/pkg/tools/token"
 )
 
+const registryStateTimeout = 5 * time.Second
+
 // Nsmgr - A simple combintation of the Endpoint, registry.NetworkServiceRegistryServer, and registry.NetworkServiceDiscoveryServer interfaces
 type Nsmgr interface {
 	networkservice.NetworkServiceServer
@@ -52,9 +59,16 @@ type Nsmgr interface {
 	registry.Registry
 }
 
+// AdminProvider - implemented by the Nsmgr created by NewServer, use a type assertion to get the admin.Registry
+type AdminProvider interface {
+	// Admin - returns admin.Registry with the live state of the Nsmgr elements, e.g. for admin.Handler
+	Admin() *admin.Registry
+}
+
 type nsmgrServer struct {
 	endpoint.Endpoint
 	registry.Registry
+	admin *admin.Registry
 }
 
 // NewServer - Creates a new Nsmgr
@@ -64,7 +78,20 @@ type nsmgrServer struct {
 //           registryCC - client connection to reach the upstream registry, could be nil, in this case only in memory storage will be used.
 // 			 clientDialOptions -  a grpc.DialOption's to be passed to GRPC connections.
 func NewServer(nsmRegistration *registryapi.NetworkServiceEndpoint, authzServer networkservice.NetworkServiceServer, tokenGenerator token.GeneratorFunc, registryCC grpc.ClientConnInterface, clientDialOptions ...grpc.DialOption) Nsmgr {
-	rv := &nsmgrServer{}
+	return NewServerWithOptions(nsmRegistration, authzServer, tokenGenerator, registryCC, WithDialOptions(clientDialOptions...))
+}
+
+// NewServerWithOptions - Creates a new Nsmgr, the same as NewServer but configured by options, e.g. WithDialOptions or
+// WithNetworkServicePolicies
+func NewServerWithOptions(nsmRegistration *registryapi.NetworkServiceEndpoint, authzServer networkservice.NetworkServiceServer, tokenGenerator token.GeneratorFunc, registryCC grpc.ClientConnInterface, options ...Option) Nsmgr {
+	opts := &serverOptions{}
+	for _, o := range options {
+		o(opts)
+	}
+
+	rv := &nsmgrServer{
+		admin: admin.NewRegistry(),
+	}
 
 	var localbypassRegistryServer registryapi.NetworkServiceEndpointRegistryServer
 
@@ -77,25 +104,29 @@ func NewServer(nsmRegistration *registryapi.NetworkServiceEndpoint, authzServer
 	nseRegistry := newRemoteNSEServer(registryCC)
 	if nseRegistry == nil {
 		nseRegistry = chain_registry.NewNetworkServiceEndpointRegistryServer(
-			setid.NewNetworkServiceEndpointRegistryServer(),  // If no remote registry then assign ID.
-			memory.NewNetworkServiceEndpointRegistryServer(), // Memory registry to store result inside.
+			refresh.NewNetworkServiceEndpointRegistryServer(), // Answer NotFound to the refreshes of unknown endpoints.
+			setid.NewNetworkServiceEndpointRegistryServer(),   // If no remote registry then assign ID.
+			memory.NewNetworkServiceEndpointRegistryServer(),  // Memory registry to store result inside.
 		)
 	}
 
+	localbypassServer := localbypass.NewServer(&localbypassRegistryServer)
+	connectServer := connect.NewServer(
+		client.NewClientFactory(nsmRegistration.Name,
+			addressof.NetworkServiceClient(
+				adapters.NewServerToClient(rv)),
+			tokenGenerator),
+		opts.dialOptions...)
+
 	// Construct Endpoint
 	rv.Endpoint = endpoint.NewServer(
 		nsmRegistration.Name,
 		authzServer,
 		tokenGenerator,
-		discover.NewServer(adapter_registry.NetworkServiceServerToClient(nsRegistry), adapter_registry.NetworkServiceEndpointServerToClient(nseRegistry)),
+		discover.NewServer(adapter_registry.NetworkServiceServerToClient(nsRegistry), adapter_registry.NetworkServiceEndpointServerToClient(nseRegistry), opts.discoverOptions...),
 		roundrobin.NewServer(),
-		localbypass.NewServer(&localbypassRegistryServer),
-		connect.NewServer(
-			client.NewClientFactory(nsmRegistration.Name,
-				addressof.NetworkServiceClient(
-					adapters.NewServerToClient(rv)),
-				tokenGenerator),
-			clientDialOptions...),
+		localbypassServer,
+		connectServer,
 	)
 
 	nsChain := chain_registry.NewNetworkServiceRegistryServer(nsRegistry)
@@ -106,9 +137,55 @@ func NewServer(nsmRegistration *registryapi.NetworkServiceEndpoint, authzServer
 	)
 	rv.Registry = registry.NewServer(nsChain, nseChain)
 
+	registerState(rv.admin, "connections", rv.Endpoint)
+	registerState(rv.admin, "connect", connectServer)
+	registerState(rv.admin, "localbypass", localbypassServer)
+	if registryCC == nil {
+		// Only the local registry contents are reported, the remote registry is not queried
+		rv.admin.Register("registry", admin.StateFunc(func() interface{} {
+			return registryState(nsRegistry, nseRegistry)
+		}))
+	}
+
 	return rv
 }
 
+func (n *nsmgrServer) Admin() *admin.Registry {
+	return n.admin
+}
+
+// registryState - returns the Network Services and the Network Service Endpoints stored in the local registry
+func registryState(nsRegistry registryapi.NetworkServiceRegistryServer, nseRegistry registryapi.NetworkServiceEndpointRegistryServer) interface{} {
+	ctx, cancel := context.WithTimeout(context.Background(), registryStateTimeout)
+	defer cancel()
+
+	state := map[string]interface{}{}
+	nsStream, err := adapter_registry.NetworkServiceServerToClient(nsRegistry).Find(ctx, &registryapi.NetworkServiceQuery{
+		NetworkService: &registryapi.NetworkService{},
+	})
+	if err != nil {
+		state["error"] = err.Error()
+		return state
+	}
+	state["network_services"] = registryapi.ReadNetworkServiceList(nsStream)
+
+	nseStream, err := adapter_registry.NetworkServiceEndpointServerToClient(nseRegistry).Find(ctx, &registryapi.NetworkServiceEndpointQuery{
+		NetworkServiceEndpoint: &registryapi.NetworkServiceEndpoint{},
+	})
+	if err != nil {
+		state["error"] = err.Error()
+		return state
+	}
+	state["network_service_endpoints"] = registryapi.ReadNetworkServiceEndpointList(nseStream)
+	return state
+}
+
+func registerState(r *admin.Registry, name string, element interface{}) {
+	if provider, ok := element.(admin.StateProvider); ok {
+		r.Register(name, provider)
+	}
+}
+
 func newRemoteNSServer(cc grpc.ClientConnInterface) registryapi.NetworkServiceRegistryServer {
 	if cc != nil {
 		return adapter_registry.NetworkServiceClientToServer(
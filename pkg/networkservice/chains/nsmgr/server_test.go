@@ -19,6 +19,9 @@ package nsmgr_test
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"os"
 	"testing"
@@ -38,6 +41,7 @@ import (
 	"github.com/networkservicemesh/sdk/pkg/networkservice/chains/nsmgr"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/common/authorize"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/testnse"
+	"github.com/networkservicemesh/sdk/pkg/tools/admin"
 	"github.com/networkservicemesh/sdk/pkg/tools/grpcutils"
 )
 
@@ -130,4 +134,45 @@ func TestNSmgrEndpointCallback(t *testing.T) {
 	require.Nil(t, err)
 	require.NotNil(t, connection)
 	require.Equal(t, 2, len(connection.Path.PathSegments))
+
+	// Check the admin state reports the connection, the connect client and the registry contents
+	w := httptest.NewRecorder()
+	admin.Handler(mgr.(nsmgr.AdminProvider).Admin()).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var state struct {
+		Connections map[string]*networkservice.Connection `json:"connections"`
+		Connect     []struct {
+			URL      string `json:"url"`
+			RefCount int    `json:"ref_count"`
+			Client   struct {
+				Heal struct {
+					Requestors []string `json:"requestors"`
+				} `json:"heal"`
+			} `json:"client"`
+		} `json:"connect"`
+		Registry struct {
+			NetworkServices []*registry.NetworkService `json:"network_services"`
+		} `json:"registry"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &state))
+	require.Contains(t, state.Connections, connection.GetId())
+	require.Len(t, state.Connect, 1)
+	require.Equal(t, nseURL.String(), state.Connect[0].URL)
+	require.Equal(t, 1, state.Connect[0].RefCount)
+	require.Len(t, state.Connect[0].Client.Heal.Requestors, 1)
+	require.Len(t, state.Registry.NetworkServices, 1)
+	require.Equal(t, nsService.Name, state.Registry.NetworkServices[0].Name)
+}
+
+func TestNSmgr_RemoteRegistryState(t *testing.T) {
+	cc, err := grpc.Dial("127.0.0.1:1", grpc.WithInsecure())
+	require.NoError(t, err)
+	defer func() { _ = cc.Close() }()
+
+	mgr := nsmgr.NewServer(&registry.NetworkServiceEndpoint{Name: "nsmgr"}, authorize.NewServer(), TokenGenerator, cc)
+
+	// The remote registry contents should not be exposed by the admin state
+	require.NotContains(t, mgr.(nsmgr.AdminProvider).Admin().Names(), "registry")
+	require.Contains(t, mgr.(nsmgr.AdminProvider).Admin().Names(), "localbypass")
 }
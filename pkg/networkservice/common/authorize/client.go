@@ -27,6 +27,8 @@ import (
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
 
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/sdk/pkg/tools/opa"
 )
 
 type authorizeClient struct {
@@ -45,14 +47,25 @@ func NewClient(opts ...Option) networkservice.NetworkServiceClient {
 }
 
 func (a *authorizeClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
-	if err := a.policies.check(ctx, request.GetConnection()); err != nil {
+	if err := a.policies.check(ctx, opa.OperationRequest, request.GetConnection()); err != nil {
 		return nil, err
 	}
-	return next.Client(ctx).Request(ctx, request, opts...)
+	conn, err := next.Client(ctx).Request(ctx, request, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if err := a.policies.check(ctx, opa.OperationResponse, conn); err != nil {
+		// the connection is not accepted, so it should not be left at the endpoint
+		if _, closeErr := next.Client(ctx).Close(ctx, conn, opts...); closeErr != nil {
+			log.Entry(ctx).Errorf("failed to close rejected connection %v: %v", conn.GetId(), closeErr)
+		}
+		return nil, err
+	}
+	return conn, nil
 }
 
 func (a *authorizeClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
-	if err := a.policies.check(ctx, conn); err != nil {
+	if err := a.policies.check(ctx, opa.OperationClose, conn); err != nil {
 		return nil, err
 	}
 	return next.Client(ctx).Close(ctx, conn, opts...)
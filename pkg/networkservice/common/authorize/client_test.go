@@ -0,0 +1,180 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authorize_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"math/big"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/spiffe/go-spiffe/v2/bundle/x509bundle"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/authorize"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/opa"
+	"github.com/networkservicemesh/sdk/pkg/tools/spiffejwt"
+)
+
+type endpointClient struct {
+	conn   *networkservice.Connection
+	closed int
+}
+
+func (c *endpointClient) Request(context.Context, *networkservice.NetworkServiceRequest, ...grpc.CallOption) (*networkservice.Connection, error) {
+	return c.conn, nil
+}
+
+func (c *endpointClient) Close(context.Context, *networkservice.Connection, ...grpc.CallOption) (*empty.Empty, error) {
+	c.closed++
+	return &empty.Empty{}, nil
+}
+
+func newCA(t *testing.T) (*x509.Certificate, crypto.Signer) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	certBytes, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(certBytes)
+	require.NoError(t, err)
+	return cert, key
+}
+
+func newSVID(t *testing.T, caCert *x509.Certificate, caKey crypto.Signer, spiffeID string) *x509svid.SVID {
+	id, err := spiffeid.FromString(spiffeID)
+	require.NoError(t, err)
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		URIs:         []*url.URL{id.URL()},
+	}
+	certBytes, err := x509.CreateCertificate(rand.Reader, template, caCert, &key.PublicKey, caKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(certBytes)
+	require.NoError(t, err)
+	return &x509svid.SVID{
+		ID:           id,
+		Certificates: []*x509.Certificate{cert},
+		PrivateKey:   key,
+	}
+}
+
+// connectionWithSubjects returns the connection with the path tokens of the subjects signed by their SVIDs issued by
+// the CA, each token audience is the next subject
+func connectionWithSubjects(t *testing.T, caCert *x509.Certificate, caKey crypto.Signer, subjects ...string) *networkservice.Connection {
+	conn := &networkservice.Connection{
+		Id:   "conn-1",
+		Path: &networkservice.Path{},
+	}
+	for i, subject := range subjects {
+		audience := ""
+		if i+1 < len(subjects) {
+			audience = subjects[i+1]
+		}
+		generateToken := spiffejwt.TokenGeneratorFunc(newSVID(t, caCert, caKey, subject), time.Hour,
+			spiffejwt.WithAudience(spiffejwt.StaticAudience(audience)))
+		token, _, err := generateToken(nil)
+		require.NoError(t, err)
+		conn.Path.PathSegments = append(conn.Path.PathSegments, &networkservice.PathSegment{Token: token})
+	}
+	return conn
+}
+
+func TestAuthzClient_ResponsePolicies(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	caCert, caKey := newCA(t)
+	anotherCACert, anotherCAKey := newCA(t)
+	id, err := spiffeid.FromString("spiffe://test.com/nse")
+	require.NoError(t, err)
+	bundle := x509bundle.FromX509Authorities(id.TrustDomain(), []*x509.Certificate{caCert})
+
+	for _, s := range []struct {
+		name     string
+		conn     *networkservice.Connection
+		rejected bool
+	}{
+		{
+			name: "expected endpoint",
+			conn: connectionWithSubjects(t, caCert, caKey, "spiffe://test.com/nsc", "spiffe://test.com/nsmgr", "spiffe://test.com/nse"),
+		},
+		{
+			name:     "unexpected endpoint",
+			conn:     connectionWithSubjects(t, caCert, caKey, "spiffe://test.com/nsc", "spiffe://test.com/nsmgr", "spiffe://test.com/evil"),
+			rejected: true,
+		},
+		{
+			name:     "untrusted endpoint",
+			conn:     connectionWithSubjects(t, anotherCACert, anotherCAKey, "spiffe://test.com/nsc", "spiffe://test.com/nsmgr", "spiffe://test.com/nse"),
+			rejected: true,
+		},
+	} {
+		s := s
+		t.Run(s.name, func(t *testing.T) {
+			endpoint := &endpointClient{conn: s.conn}
+			client := next.NewNetworkServiceClient(
+				authorize.NewClient(
+					authorize.WithTrustBundle(bundle),
+					authorize.WithResponsePolicies(
+						opa.WithTokenChainPolicy(),
+						opa.WithEndpointSpiffeIDPolicy("spiffe://test.com/nse"),
+					),
+				),
+				endpoint,
+			)
+
+			conn, err := client.Request(context.Background(), &networkservice.NetworkServiceRequest{})
+			if !s.rejected {
+				require.NoError(t, err)
+				require.Equal(t, s.conn, conn)
+				require.Equal(t, 0, endpoint.closed)
+				return
+			}
+			require.Error(t, err)
+			require.Equal(t, codes.PermissionDenied, status.Code(err))
+			require.Nil(t, conn)
+			require.Equal(t, 1, endpoint.closed)
+		})
+	}
+}
@@ -18,21 +18,106 @@ package authorize
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
 
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/spiffe/go-spiffe/v2/bundle/x509bundle"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/networkservicemesh/sdk/pkg/tools/opa"
 )
 
+const (
+	// DeniedReason is ErrorInfo reason of the denied requests
+	DeniedReason = "POLICY_DENIED"
+	// DeniedDomain is ErrorInfo domain of the denied requests
+	DeniedDomain = "authorize.networkservicemesh.io"
+	// PolicyMetadataKey is ErrorInfo metadata key of the name of the policy denied the request
+	PolicyMetadataKey = "policy"
+)
+
 type authorizePolicies struct {
-	policies []opa.AuthorizationPolicy
+	policies         []opa.AuthorizationPolicy
+	responsePolicies []opa.AuthorizationPolicy
+	trustBundle      x509bundle.Source
+	sinks            []DecisionSink
 }
 
-func (a *authorizePolicies) check(ctx context.Context, conn *networkservice.Connection) error {
-	for _, p := range a.policies {
-		if err := p.Check(ctx, conn.GetPath()); err != nil {
-			return err
+func (a *authorizePolicies) check(ctx context.Context, operation opa.Operation, conn *networkservice.Connection) error {
+	if a.trustBundle != nil {
+		ctx = opa.WithTrustBundle(ctx, a.trustBundle)
+	}
+	policies := a.policies
+	if operation == opa.OperationResponse {
+		policies = a.responsePolicies
+	}
+	input := opa.NewConnectionInput(ctx, operation, conn)
+	var inputHash string
+	if len(a.sinks) > 0 {
+		inputHash = hashOf(input)
+	}
+	for _, p := range policies {
+		err := p.Check(ctx, input)
+		if len(a.sinks) > 0 {
+			a.record(ctx, &Decision{
+				Time:         time.Now(),
+				Policy:       opa.PolicyName(p),
+				Operation:    operation,
+				Allowed:      err == nil,
+				Error:        evaluationError(err),
+				InputHash:    inputHash,
+				SpiffeID:     input.SpiffeID,
+				ConnectionID: conn.GetId(),
+			})
+		}
+		if err != nil {
+			return withPolicyDetails(err, opa.PolicyName(p))
 		}
 	}
 	return nil
 }
+
+func (a *authorizePolicies) record(ctx context.Context, decision *Decision) {
+	for _, sink := range a.sinks {
+		sink.Record(ctx, decision)
+	}
+}
+
+func hashOf(input *opa.ConnectionInput) string {
+	b, err := json.Marshal(input)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+// evaluationError returns the message of err if the policy is failed to evaluate rather than denied
+func evaluationError(err error) string {
+	if err == nil || status.Code(err) == codes.PermissionDenied {
+		return ""
+	}
+	return err.Error()
+}
+
+// withPolicyDetails adds ErrorInfo with the policy name to the status of the denial err
+func withPolicyDetails(err error, policy string) error {
+	s, ok := status.FromError(err)
+	if !ok || s.Code() != codes.PermissionDenied {
+		return err
+	}
+	detailed, detailsErr := s.WithDetails(&errdetails.ErrorInfo{
+		Reason:   DeniedReason,
+		Domain:   DeniedDomain,
+		Metadata: map[string]string{PolicyMetadataKey: policy},
+	})
+	if detailsErr != nil {
+		return err
+	}
+	return detailed.Err()
+}
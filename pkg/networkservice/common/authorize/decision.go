@@ -0,0 +1,109 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authorize
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+
+	stan "github.com/nats-io/stan.go"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/sdk/pkg/tools/opa"
+)
+
+// Decision is a record of a single policy check
+type Decision struct {
+	// Time is the time of the check
+	Time time.Time `json:"time"`
+	// Policy is the name of the checked policy
+	Policy string `json:"policy"`
+	// Operation is the authorized operation
+	Operation opa.Operation `json:"operation"`
+	// Allowed is the result of the policy query
+	Allowed bool `json:"allowed"`
+	// Error is the policy evaluation error, empty if the policy is evaluated
+	Error string `json:"error,omitempty"`
+	// InputHash is hex encoded SHA-256 of the JSON policy input
+	InputHash string `json:"input_hash"`
+	// SpiffeID is SPIFFE ID of the caller, empty if the caller has no SVID
+	SpiffeID string `json:"spiffe_id"`
+	// ConnectionID is ID of the authorized connection
+	ConnectionID string `json:"connection_id"`
+}
+
+// DecisionSink records authorization decisions
+type DecisionSink interface {
+	// Record records the decision, it should not block for long as it is called on the request path
+	Record(ctx context.Context, decision *Decision)
+}
+
+// DecisionSinkFunc is a function adapter for DecisionSink
+type DecisionSinkFunc func(ctx context.Context, decision *Decision)
+
+// Record calls f(ctx, decision)
+func (f DecisionSinkFunc) Record(ctx context.Context, decision *Decision) {
+	f(ctx, decision)
+}
+
+// NewLogDecisionSink creates DecisionSink logging decisions with the logger from the context: allowed decisions are
+// logged with Info level, denied ones with Warn level
+func NewLogDecisionSink() DecisionSink {
+	return DecisionSinkFunc(func(ctx context.Context, decision *Decision) {
+		entry := log.Entry(ctx).WithField("authorize", decision)
+		if decision.Allowed {
+			entry.Infof("policy %v allowed %v of %v", decision.Policy, decision.Operation, decision.ConnectionID)
+			return
+		}
+		entry.Warnf("policy %v denied %v of %v", decision.Policy, decision.Operation, decision.ConnectionID)
+	})
+}
+
+// NewWriterDecisionSink creates DecisionSink writing decisions to w as JSON lines, e.g. to an audit file
+func NewWriterDecisionSink(w io.Writer) DecisionSink {
+	var mutex sync.Mutex
+	encoder := json.NewEncoder(w)
+	return DecisionSinkFunc(func(ctx context.Context, decision *Decision) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		if err := encoder.Encode(decision); err != nil {
+			log.Entry(ctx).Errorf("failed to write authorization decision: %v", err)
+		}
+	})
+}
+
+// NewJournalDecisionSink creates DecisionSink publishing JSON decisions to the NATS streaming journal with the name
+// journalID
+func NewJournalDecisionSink(journalID string, stanConn stan.Conn) DecisionSink {
+	return DecisionSinkFunc(func(ctx context.Context, decision *Decision) {
+		if err := publishDecision(journalID, stanConn, decision); err != nil {
+			log.Entry(ctx).Errorf("failed to publish authorization decision: %v", err)
+		}
+	})
+}
+
+func publishDecision(journalID string, stanConn stan.Conn, decision *Decision) error {
+	js, err := json.Marshal(decision)
+	if err != nil {
+		return err
+	}
+	return errors.Wrapf(stanConn.Publish(journalID, js), "journal %v", journalID)
+}
@@ -0,0 +1,89 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authorize_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/authorize"
+	"github.com/networkservicemesh/sdk/pkg/tools/opa"
+)
+
+func TestAuthzEndpoint_Decisions(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	var decisions []*authorize.Decision
+	sink := authorize.DecisionSinkFunc(func(_ context.Context, decision *authorize.Decision) {
+		decisions = append(decisions, decision)
+	})
+	buffer := new(bytes.Buffer)
+
+	srv := authorize.NewServer(
+		authorize.WithPolicies(testPolicy()),
+		authorize.WithDecisionSinks(sink, authorize.NewWriterDecisionSink(buffer)),
+	)
+
+	request := requestWithToken("allowed")
+	request.Connection.Id = "conn-1"
+	_, err := srv.Request(context.Background(), request)
+	require.NoError(t, err)
+
+	request = requestWithToken("not_allowed")
+	request.Connection.Id = "conn-2"
+	_, err = srv.Request(context.Background(), request)
+	require.Error(t, err)
+
+	s, ok := status.FromError(err)
+	require.True(t, ok)
+	require.Equal(t, codes.PermissionDenied, s.Code())
+	require.Len(t, s.Details(), 1)
+	info, ok := s.Details()[0].(*errdetails.ErrorInfo)
+	require.True(t, ok)
+	require.Equal(t, authorize.DeniedReason, info.Reason)
+	require.Equal(t, "allow", info.Metadata[authorize.PolicyMetadataKey])
+
+	require.Len(t, decisions, 2)
+	require.True(t, decisions[0].Allowed)
+	require.Equal(t, "conn-1", decisions[0].ConnectionID)
+	require.False(t, decisions[1].Allowed)
+	require.Empty(t, decisions[1].Error)
+	require.Equal(t, "conn-2", decisions[1].ConnectionID)
+	for _, d := range decisions {
+		require.Equal(t, "allow", d.Policy)
+		require.Equal(t, opa.OperationRequest, d.Operation)
+		require.Len(t, d.InputHash, 64)
+	}
+	require.NotEqual(t, decisions[0].InputHash, decisions[1].InputHash)
+
+	decoder := json.NewDecoder(buffer)
+	for i := range decisions {
+		decision := new(authorize.Decision)
+		require.NoError(t, decoder.Decode(decision))
+		require.Equal(t, decisions[i].ConnectionID, decision.ConnectionID)
+		require.Equal(t, decisions[i].Allowed, decision.Allowed)
+	}
+	require.False(t, decoder.More())
+}
@@ -0,0 +1,99 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authorize
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/opa"
+)
+
+type connectionLifetime struct {
+	started  time.Time
+	lastSeen time.Time
+}
+
+type maxPathLifetimePolicy struct {
+	maxLifetime time.Duration
+	mutex       sync.Mutex
+	connections map[string]*connectionLifetime
+}
+
+// MaxPathLifetimePolicy returns native policy denying requests for connections established more than maxLifetime
+// ago, so refreshes cannot keep a connection alive longer than maxLifetime. The connection lifetime starts with the
+// first request with its ID and ends on close. Connections neither refreshed nor closed for maxLifetime are forgotten.
+func MaxPathLifetimePolicy(maxLifetime time.Duration) opa.AuthorizationPolicy {
+	return &maxPathLifetimePolicy{
+		maxLifetime: maxLifetime,
+		connections: make(map[string]*connectionLifetime),
+	}
+}
+
+func (p *maxPathLifetimePolicy) Name() string {
+	return "max_path_lifetime"
+}
+
+func (p *maxPathLifetimePolicy) Check(_ context.Context, input interface{}) error {
+	connInput, ok := input.(*opa.ConnectionInput)
+	if !ok {
+		return status.Errorf(codes.Internal, "unexpected input %T", input)
+	}
+	id := connInput.Connection.GetId()
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	now := time.Now()
+	p.forgetStale(now)
+
+	switch connInput.Operation {
+	case opa.OperationClose:
+		delete(p.connections, id)
+		return nil
+	case opa.OperationRequest:
+	default:
+		return nil
+	}
+
+	lifetime, ok := p.connections[id]
+	if !ok {
+		p.connections[id] = &connectionLifetime{
+			started:  now,
+			lastSeen: now,
+		}
+		return nil
+	}
+	lifetime.lastSeen = now
+	if now.Sub(lifetime.started) > p.maxLifetime {
+		return status.Errorf(codes.PermissionDenied, "connection %v lifetime exceeds %v", id, p.maxLifetime)
+	}
+	return nil
+}
+
+// forgetStale removes the connections not seen for maxLifetime, p.mutex should be locked
+func (p *maxPathLifetimePolicy) forgetStale(now time.Time) {
+	for id, lifetime := range p.connections {
+		if now.Sub(lifetime.lastSeen) > p.maxLifetime {
+			delete(p.connections, id)
+		}
+	}
+}
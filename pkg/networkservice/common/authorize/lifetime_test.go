@@ -0,0 +1,58 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authorize_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/authorize"
+)
+
+func TestMaxPathLifetimePolicy(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	const maxLifetime = 100 * time.Millisecond
+	srv := authorize.NewServer(authorize.WithPolicies(authorize.MaxPathLifetimePolicy(maxLifetime)))
+	request := requestWithClaims(&jwt.StandardClaims{Subject: "spiffe://test.com/nsc"})
+
+	_, err := srv.Request(context.Background(), request)
+	require.NoError(t, err)
+
+	// refreshes within the lifetime are allowed
+	time.Sleep(maxLifetime / 2)
+	_, err = srv.Request(context.Background(), request)
+	require.NoError(t, err)
+
+	// refreshes don't extend the lifetime
+	time.Sleep(maxLifetime / 2)
+	_, err = srv.Request(context.Background(), request)
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// closed connection may be requested again
+	_, err = srv.Close(context.Background(), request.GetConnection())
+	require.NoError(t, err)
+	_, err = srv.Request(context.Background(), request)
+	require.NoError(t, err)
+}
@@ -19,6 +19,8 @@
 package authorize
 
 import (
+	"github.com/spiffe/go-spiffe/v2/bundle/x509bundle"
+
 	"github.com/networkservicemesh/sdk/pkg/tools/opa"
 )
 
@@ -47,6 +49,30 @@ func WithDefaultPolicies() Option {
 	})
 }
 
+// WithTrustBundle sets SPIFFE trust bundle to verify path tokens signatures, see opa.WithTokensSignedPolicy
+func WithTrustBundle(source x509bundle.Source) Option {
+	return optionFunc(func(a *authorizePolicies) {
+		a.trustBundle = source
+	})
+}
+
+// WithResponsePolicies sets policies checking the connection returned by Request to the client, e.g.
+// opa.WithTokenChainPolicy, opa.WithEndpointSpiffeIDPolicy and opa.WithTokensOutliveRefreshPolicy. The policies are
+// evaluated with "response" operation, they are ignored by the server. opa.WithEndpointSpiffeIDPolicy verifies the
+// endpoint token signature, so it requires WithTrustBundle.
+func WithResponsePolicies(policies ...opa.AuthorizationPolicy) Option {
+	return optionFunc(func(a *authorizePolicies) {
+		a.responsePolicies = append(a.responsePolicies, policies...)
+	})
+}
+
+// WithDecisionSinks sets sinks recording the decision of every checked policy
+func WithDecisionSinks(sinks ...DecisionSink) Option {
+	return optionFunc(func(a *authorizePolicies) {
+		a.sinks = append(a.sinks, sinks...)
+	})
+}
+
 type optionFunc func(*authorizePolicies)
 
 func (f optionFunc) apply(a *authorizePolicies) {
@@ -0,0 +1,70 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authorize
+
+import (
+	"context"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/opa"
+	"github.com/networkservicemesh/sdk/pkg/tools/revocation"
+)
+
+type revocationPolicy struct {
+	list *revocation.List
+}
+
+// RevocationPolicy returns native policy denying connections with the caller or any path token subject revoked by
+// the list, or with any path token ID (jti claim) revoked by the list
+func RevocationPolicy(list *revocation.List) opa.AuthorizationPolicy {
+	return &revocationPolicy{
+		list: list,
+	}
+}
+
+func (p *revocationPolicy) Name() string {
+	return "revocation"
+}
+
+func (p *revocationPolicy) Check(_ context.Context, input interface{}) error {
+	var segments []*networkservice.PathSegment
+	switch v := input.(type) {
+	case *opa.ConnectionInput:
+		if p.list.IsRevoked(v.SpiffeID, "") {
+			return status.Errorf(codes.PermissionDenied, "caller %v is revoked", v.SpiffeID)
+		}
+		segments = v.PathSegments
+	case *networkservice.Path:
+		segments = v.GetPathSegments()
+	default:
+		return status.Errorf(codes.Internal, "unexpected input %T", input)
+	}
+	for i, segment := range segments {
+		claims := new(jwt.StandardClaims)
+		if _, _, err := new(jwt.Parser).ParseUnverified(segment.GetToken(), claims); err != nil {
+			return status.Errorf(codes.PermissionDenied, "path segment %d token is invalid: %v", i, err)
+		}
+		if p.list.IsRevoked(claims.Subject, claims.Id) {
+			return status.Errorf(codes.PermissionDenied, "path segment %d token is revoked", i)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authorize_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/authorize"
+	"github.com/networkservicemesh/sdk/pkg/tools/revocation"
+)
+
+func requestWithClaims(claims ...*jwt.StandardClaims) *networkservice.NetworkServiceRequest {
+	request := &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id:   "conn-1",
+			Path: &networkservice.Path{},
+		},
+	}
+	for _, c := range claims {
+		token, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, c).SignedString([]byte("secret"))
+		request.Connection.Path.PathSegments = append(request.Connection.Path.PathSegments, &networkservice.PathSegment{Token: token})
+	}
+	return request
+}
+
+func TestRevocationPolicy(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	list := revocation.NewList()
+	srv := authorize.NewServer(authorize.WithPolicies(authorize.RevocationPolicy(list)))
+
+	request := requestWithClaims(
+		&jwt.StandardClaims{Subject: "spiffe://test.com/nsc", Id: "token-1"},
+		&jwt.StandardClaims{Subject: "spiffe://test.com/nsmgr", Id: "token-2"},
+	)
+	_, err := srv.Request(context.Background(), request)
+	require.NoError(t, err)
+
+	list.Update(&revocation.Document{TokenIDs: []string{"token-2"}})
+	_, err = srv.Request(context.Background(), request)
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	list.Update(&revocation.Document{SpiffeIDs: []string{"spiffe://test.com/nsc"}})
+	_, err = srv.Request(context.Background(), request)
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	list.Update(&revocation.Document{})
+	_, err = srv.Request(context.Background(), request)
+	require.NoError(t, err)
+}
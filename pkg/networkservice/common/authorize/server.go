@@ -27,6 +27,7 @@ import (
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
 
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/opa"
 )
 
 type authorizeServer struct {
@@ -45,14 +46,14 @@ func NewServer(opts ...Option) networkservice.NetworkServiceServer {
 }
 
 func (a *authorizeServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
-	if err := a.policies.check(ctx, request.GetConnection()); err != nil {
+	if err := a.policies.check(ctx, opa.OperationRequest, request.GetConnection()); err != nil {
 		return nil, err
 	}
 	return next.Server(ctx).Request(ctx, request)
 }
 
 func (a *authorizeServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
-	if err := a.policies.check(ctx, conn); err != nil {
+	if err := a.policies.check(ctx, opa.OperationClose, conn); err != nil {
 		return nil, err
 	}
 	return next.Server(ctx).Close(ctx, conn)
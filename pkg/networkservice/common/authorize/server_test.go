@@ -104,3 +104,46 @@ func TestAuthzEndpoint(t *testing.T) {
 		})
 	}
 }
+
+func TestAuthzEndpoint_ConnectionInput(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	policy := opa.WithPolicyFromSource(`
+		package test
+
+		default allow = false
+
+		allow {
+			input.version = 1
+			input.operation = "close"
+		}
+
+		allow {
+			input.operation = "request"
+			input.network_service = "allowed-ns"
+			input.labels.app = "test"
+			input.mechanism_type = "KERNEL"
+			input.path_segments[input.index].token = "token"
+		}
+`, "allow", opa.True)
+
+	request := func(ns string) *networkservice.NetworkServiceRequest {
+		r := requestWithToken("token")
+		r.Connection.NetworkService = ns
+		r.Connection.Labels = map[string]string{"app": "test"}
+		r.Connection.Mechanism = &networkservice.Mechanism{Type: "KERNEL"}
+		return r
+	}
+
+	srv := authorize.NewServer(authorize.WithPolicies(policy))
+
+	_, err := srv.Request(context.Background(), request("allowed-ns"))
+	require.NoError(t, err)
+
+	_, err = srv.Request(context.Background(), request("denied-ns"))
+	require.Error(t, err)
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = srv.Close(context.Background(), request("denied-ns").GetConnection())
+	require.NoError(t, err)
+}
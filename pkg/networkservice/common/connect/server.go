@@ -22,9 +22,12 @@ package connect
 import (
 	"context"
 	"net/url"
+	"sort"
 
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/trace"
+	"github.com/networkservicemesh/sdk/pkg/tools/admin"
 	"github.com/networkservicemesh/sdk/pkg/tools/grpcutils"
+	"github.com/networkservicemesh/sdk/pkg/tools/nsmerrors"
 	"github.com/networkservicemesh/sdk/pkg/tools/serialize"
 
 	"github.com/golang/protobuf/ptypes/empty"
@@ -45,6 +48,17 @@ type clientEntry struct {
 	connections     map[string]*networkservice.Connection
 	err             error
 	closeConnection func() error
+	state           *admin.Registry // State of the client chain elements, e.g. heal
+}
+
+// clientState is the reported state of the clientEntry
+type clientState struct {
+	URL         string      `json:"url"`
+	RefCount    int         `json:"ref_count"`
+	Connections []string    `json:"connections"`
+	Ready       bool        `json:"ready"`
+	Error       string      `json:"error,omitempty"`
+	Client      interface{} `json:"client,omitempty"`
 }
 
 func (ce *clientEntry) markAsReady() {
@@ -165,6 +179,7 @@ func (c *connectServer) findOrCreateClient(ctx context.Context, clientURI *url.U
 				clientURI:   clientURI,
 				ready:       make(chan struct{}),
 				connections: map[string]*networkservice.Connection{},
+				state:       admin.NewRegistry(),
 			}
 			// Put/Update connection
 			c.clients[clientURI.String()] = ce
@@ -181,7 +196,8 @@ func (c *connectServer) findOrCreateClient(ctx context.Context, clientURI *url.U
 				delete(ce.connections, conn.Id)
 				c.closeClient(ctx, ce)
 			})
-			return nil, errors.Errorf("context timeout")
+			return nil, nsmerrors.New(nsmerrors.ReasonDialTimeout, "context timeout").
+				WithMetadata("url", clientURI.String())
 		case <-ce.ready:
 			// all is fine, just return
 			if ce.err != nil {
@@ -230,14 +246,15 @@ func (c *connectServer) closeClient(ctx context.Context, ce *clientEntry) {
 func (c *connectServer) createClient(ce *clientEntry) (err error) {
 	// Opening GPRC connection
 	// we should open a connecton with specified server, and we should be sure we do this once.
-	clientCtx, _ := context.WithCancel(context.Background())
+	clientCtx, _ := context.WithCancel(admin.WithRegistry(context.Background(), ce.state))
 
 	dialOptions := c.dialOptions
 
 	// Dial with connection
 	ce.cc, ce.closeConnection, err = grpcDialer(clientCtx, grpcutils.URLToTarget(ce.clientURI), dialOptions...)
 	if err != nil {
-		return errors.Wrapf(err, "unable to dial %s", ce.clientURI.String())
+		return nsmerrors.Wrap(err, nsmerrors.ReasonDialFailed, "unable to dial %s", ce.clientURI.String()).
+			WithMetadata("url", ce.clientURI.String())
 	}
 
 	// Initialize client and factory
@@ -254,10 +271,47 @@ func (c *connectServer) clientURL(ctx context.Context, connection *networkservic
 		})
 	}
 	if clientURL == nil {
-		return nil, errors.Errorf("a proper clienturl.ClientURL should be passed or request.Connection should be active. Connection: %v", connection)
+		return nil, nsmerrors.New(nsmerrors.ReasonNoClientURL, "a proper clienturl.ClientURL should be passed or request.Connection should be active. Connection: %v", connection)
 	}
 	return clientURL, nil
 }
+
+// State - returns the states of the client entries with their reference counts
+func (c *connectServer) State() interface{} {
+	var entries []*clientEntry
+	states := []*clientState{}
+	<-c.executor.AsyncExec(func() {
+		for _, ce := range c.clients {
+			state := &clientState{
+				URL:      ce.clientURI.String(),
+				RefCount: len(ce.connections),
+			}
+			for id := range ce.connections {
+				state.Connections = append(state.Connections, id)
+			}
+			sort.Strings(state.Connections)
+			select {
+			case <-ce.ready:
+				state.Ready = true
+				if ce.err != nil {
+					state.Error = ce.err.Error()
+				}
+			default:
+			}
+			entries = append(entries, ce)
+			states = append(states, state)
+		}
+	})
+	// Client states are collected out of the executor, since the client elements have their own synchronization
+	for i, ce := range entries {
+		if states[i].Ready {
+			states[i].Client = ce.state.State()
+		}
+	}
+	sort.Slice(states, func(i, j int) bool { return states[i].URL < states[j].URL })
+	return states
+}
+
 func grpcDialer(ctx context.Context, target string, opts ...grpc.DialOption) (grpc.ClientConnInterface, func() error, error) {
 	grpcCon, err := grpc.DialContext(ctx, target, opts...)
 	if err != nil {
@@ -0,0 +1,38 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package discover
+
+import (
+	"github.com/spiffe/go-spiffe/v2/bundle/x509bundle"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/nspolicy"
+)
+
+// Option is an option pattern for NewServer
+type Option func(d *discoverCandidatesServer)
+
+// WithNetworkServicePolicies enables checking the requests against the authorization policies published for the
+// network services (see nspolicy.Publish). The policies are checked for the client which has originated the
+// connection: the token of the first path segment should be signed by the client X509-SVID verified by the bundle.
+// Requests for the network services without a policy are not checked, requests for the network services with an
+// invalid policy are denied.
+func WithNetworkServicePolicies(bundle x509bundle.Source) Option {
+	return func(d *discoverCandidatesServer) {
+		d.bundle = bundle
+		d.policies = nspolicy.NewCache(nspolicy.DefaultCacheSize)
+	}
+}
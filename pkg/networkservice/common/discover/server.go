@@ -19,27 +19,41 @@ package discover
 import (
 	"context"
 
+	"github.com/dgrijalva/jwt-go"
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/pkg/errors"
+	"github.com/spiffe/go-spiffe/v2/bundle/x509bundle"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
 	"github.com/networkservicemesh/api/pkg/api/registry"
 
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/nsmerrors"
+	"github.com/networkservicemesh/sdk/pkg/tools/nspolicy"
+	"github.com/networkservicemesh/sdk/pkg/tools/opa"
+	"github.com/networkservicemesh/sdk/pkg/tools/spiffejwt"
 )
 
 type discoverCandidatesServer struct {
 	nseClient registry.NetworkServiceEndpointRegistryClient
 	nsClient  registry.NetworkServiceRegistryClient
+	bundle    x509bundle.Source
+	policies  *nspolicy.Cache
 }
 
 // NewServer - creates a new NetworkServiceServer that can discover possible candidates for providing a requested
 //             Network Service and add it to the context.Context where it can be retrieved by Candidates(ctx)
-func NewServer(nsClient registry.NetworkServiceRegistryClient, nseClient registry.NetworkServiceEndpointRegistryClient) networkservice.NetworkServiceServer {
-	return &discoverCandidatesServer{
+func NewServer(nsClient registry.NetworkServiceRegistryClient, nseClient registry.NetworkServiceEndpointRegistryClient, options ...Option) networkservice.NetworkServiceServer {
+	d := &discoverCandidatesServer{
 		nseClient: nseClient,
 		nsClient:  nsClient,
 	}
+	for _, o := range options {
+		o(d)
+	}
+	return d
 }
 
 func (d *discoverCandidatesServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
@@ -68,12 +82,56 @@ func (d *discoverCandidatesServer) Request(ctx context.Context, request *network
 		return nil, errors.WithStack(err)
 	}
 
-	nsList := registry.ReadNetworkServiceList(nsStream)
+	nsList := networkServices(registry.ReadNetworkServiceList(nsStream))
+	if len(nsList) == 0 {
+		return nil, nsmerrors.New(nsmerrors.ReasonNetworkServiceNotFound, "network service %s is not found", request.GetConnection().GetNetworkService()).
+			WithMetadata("network_service", request.GetConnection().GetNetworkService())
+	}
+	if err := d.checkPolicy(ctx, request.GetConnection(), nsList[0]); err != nil {
+		return nil, err
+	}
 	nseList = matchEndpoint(request.GetConnection().GetLabels(), nsList[0], nseList)
 	ctx = WithCandidates(ctx, nseList, nsList[0])
 	return next.Server(ctx).Request(ctx, request)
 }
 
+// checkPolicy checks conn against the policy of its network service ns if the policies are enabled
+func (d *discoverCandidatesServer) checkPolicy(ctx context.Context, conn *networkservice.Connection, ns *registry.NetworkService) error {
+	if d.policies == nil {
+		return nil
+	}
+	doc, err := nspolicy.Find(ctx, d.nsClient, ns.GetName())
+	if err != nil {
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+	if doc == nil {
+		return nil
+	}
+	input := opa.NewConnectionInput(ctx, opa.OperationRequest, conn)
+	segments := conn.GetPath().GetPathSegments()
+	if len(segments) == 0 {
+		return status.Errorf(codes.PermissionDenied, "no path to check the policy of network service %v", ns.GetName())
+	}
+	id, err := spiffejwt.Verify(segments[0].GetToken(), d.bundle, &jwt.StandardClaims{})
+	if err != nil {
+		return status.Errorf(codes.PermissionDenied, "invalid token of the client: %v", err)
+	}
+	input.SpiffeID = id.String()
+	return d.policies.Policy(doc).Check(ctx, input)
+}
+
+// networkServices filters the policy network services out of nsList, they are found together with the network services
+// by name
+func networkServices(nsList []*registry.NetworkService) []*registry.NetworkService {
+	var rv []*registry.NetworkService
+	for _, ns := range nsList {
+		if _, ok := nspolicy.NetworkServiceName(ns.GetName()); !ok {
+			rv = append(rv, ns)
+		}
+	}
+	return rv
+}
+
 func (d *discoverCandidatesServer) Close(context.Context, *networkservice.Connection) (*empty.Empty, error) {
 	return &empty.Empty{}, nil
 }
@@ -19,21 +19,37 @@ package discover_test
 
 import (
 	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"math/big"
+	"net/url"
 	"testing"
+	"time"
 
 	"github.com/networkservicemesh/sdk/pkg/registry/common/setid"
 	"github.com/networkservicemesh/sdk/pkg/registry/core/adapters"
 	"github.com/networkservicemesh/sdk/pkg/registry/memory"
 
+	"github.com/dgrijalva/jwt-go"
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
 	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/spiffe/go-spiffe/v2/bundle/x509bundle"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/goleak"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/networkservicemesh/sdk/pkg/networkservice/common/discover"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/checks/checkcontext"
 	registrynext "github.com/networkservicemesh/sdk/pkg/registry/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/nspolicy"
+	"github.com/networkservicemesh/sdk/pkg/tools/spiffejwt"
 )
 
 func endpoints() []*registry.NetworkServiceEndpoint {
@@ -269,3 +285,139 @@ func TestMatchNothing(t *testing.T) {
 	_, err = server.Request(context.Background(), request)
 	require.Nil(t, err)
 }
+
+func newCA(t *testing.T) (*x509.Certificate, crypto.Signer) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	certBytes, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(certBytes)
+	require.NoError(t, err)
+	return cert, key
+}
+
+func newToken(t *testing.T, caCert *x509.Certificate, caKey crypto.Signer, spiffeID string) string {
+	id := spiffeid.RequireFromString(spiffeID)
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		URIs:         []*url.URL{id.URL()},
+	}
+	certBytes, err := x509.CreateCertificate(rand.Reader, template, caCert, &key.PublicKey, caKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(certBytes)
+	require.NoError(t, err)
+	token, err := spiffejwt.Sign(&x509svid.SVID{
+		ID:           id,
+		Certificates: []*x509.Certificate{cert},
+		PrivateKey:   key,
+	}, &jwt.StandardClaims{
+		Subject:   spiffeID,
+		ExpiresAt: time.Now().Add(time.Hour).Unix(),
+	})
+	require.NoError(t, err)
+	return token
+}
+
+func TestNetworkServicePolicies(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	caCert, caKey := newCA(t)
+	anotherCACert, anotherCAKey := newCA(t)
+	bundle := x509bundle.FromX509Authorities(spiffeid.RequireTrustDomainFromString("test.com"), []*x509.Certificate{caCert})
+
+	nsName := networkServiceName()
+	nsServer := memory.NewNetworkServiceRegistryServer()
+	_, err := nsServer.Register(context.Background(), &registry.NetworkService{
+		Name: nsName,
+	})
+	require.Nil(t, err)
+	nseServer := registrynext.NewNetworkServiceEndpointRegistryServer(setid.NewNetworkServiceEndpointRegistryServer(), memory.NewNetworkServiceEndpointRegistryServer())
+	for _, nse := range endpoints() {
+		_, err = nseServer.Register(context.Background(), nse)
+		require.Nil(t, err)
+	}
+	nsClient := adapters.NetworkServiceServerToClient(nsServer)
+
+	server := next.NewNetworkServiceServer(
+		discover.NewServer(nsClient, adapters.NetworkServiceEndpointServerToClient(nseServer), discover.WithNetworkServicePolicies(bundle)),
+	)
+	request := func(app, token string) *networkservice.NetworkServiceRequest {
+		return &networkservice.NetworkServiceRequest{
+			Connection: &networkservice.Connection{
+				NetworkService: nsName,
+				Labels:         map[string]string{"app": app},
+				Path: &networkservice.Path{
+					PathSegments: []*networkservice.PathSegment{{Token: token}},
+				},
+			},
+		}
+	}
+	nsc := newToken(t, caCert, caKey, "spiffe://test.com/nsc")
+	evil := newToken(t, caCert, caKey, "spiffe://test.com/evil")
+	untrusted := newToken(t, anotherCACert, anotherCAKey, "spiffe://test.com/nsc")
+
+	// no policy is published
+	_, err = server.Request(context.Background(), request("firewall", evil))
+	require.Nil(t, err)
+
+	require.Nil(t, nspolicy.Publish(context.Background(), nsClient, nsName, &nspolicy.Document{
+		AllowedSpiffeIDs: []string{"spiffe://test.com/nsc"},
+		Rego: `
+			package test
+
+			default allow = false
+
+			allow {
+				input.labels.app = "firewall"
+			}
+		`,
+	}))
+
+	_, err = server.Request(context.Background(), request("firewall", nsc))
+	require.Nil(t, err)
+
+	_, err = server.Request(context.Background(), request("vpn-gateway", nsc))
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = server.Request(context.Background(), request("firewall", evil))
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = server.Request(context.Background(), request("firewall", untrusted))
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// policy using the network
+	require.Nil(t, nspolicy.Publish(context.Background(), nsClient, nsName, &nspolicy.Document{
+		Rego: `
+			package test
+
+			allow {
+				http.send({"method": "get", "url": "http://127.0.0.1:1"})
+			}
+		`,
+	}))
+
+	_, err = server.Request(context.Background(), request("firewall", nsc))
+	require.Error(t, err)
+
+	// invalid policy
+	_, err = nsServer.Register(context.Background(), &registry.NetworkService{
+		Name:    nspolicy.PolicyName(nsName),
+		Payload: "{",
+	})
+	require.Nil(t, err)
+
+	_, err = server.Request(context.Background(), request("firewall", nsc))
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+}
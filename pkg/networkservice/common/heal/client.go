@@ -19,6 +19,7 @@ package heal
 
 import (
 	"context"
+	"sort"
 	"time"
 
 	"github.com/golang/protobuf/ptypes/empty"
@@ -29,6 +30,8 @@ import (
 
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/trace"
 	"github.com/networkservicemesh/sdk/pkg/tools/addressof"
+	"github.com/networkservicemesh/sdk/pkg/tools/admin"
+	"github.com/networkservicemesh/sdk/pkg/tools/closectx"
 	"github.com/networkservicemesh/sdk/pkg/tools/extend"
 	"github.com/networkservicemesh/sdk/pkg/tools/serialize"
 
@@ -79,9 +82,38 @@ func NewClient(ctx context.Context, client networkservice.MonitorConnectionClien
 
 	rv.init()
 
+	admin.RegistryFromContext(ctx).Register("heal", rv)
+
 	return rv
 }
 
+// healState is the reported state of the healClient
+type healState struct {
+	Monitoring bool     `json:"monitoring"`
+	Requestors []string `json:"requestors"`
+	Reported   []string `json:"reported"`
+}
+
+// State - returns IDs of the connections to heal and of the connections reported by the monitor
+func (f *healClient) State() interface{} {
+	state := &healState{
+		Requestors: []string{},
+		Reported:   []string{},
+	}
+	<-f.updateExecutor.AsyncExec(func() {
+		state.Monitoring = f.eventReceiver != nil
+		for id := range f.requestors {
+			state.Requestors = append(state.Requestors, id)
+		}
+		for id := range f.reported {
+			state.Reported = append(state.Reported, id)
+		}
+	})
+	sort.Strings(state.Requestors)
+	sort.Strings(state.Reported)
+	return state
+}
+
 func (f *healClient) init() {
 	if f.eventReceiver != nil {
 		select {
@@ -177,7 +209,7 @@ func (f *healClient) Request(ctx context.Context, request *networkservice.Networ
 		f.closers[req.GetConnection().GetId()] = func() {
 			timeCtx, cancelFunc := context.WithTimeout(f.chainContext, duration)
 			defer cancelFunc()
-			ctx = extend.WithValuesFromContext(timeCtx, ctx)
+			ctx = closectx.WithReason(extend.WithValuesFromContext(timeCtx, ctx), closectx.ReasonHeal)
 			_, err := (*f.onHeal).Close(ctx, req.GetConnection(), opts...)
 			if err != nil {
 				trace.Log(ctx).Errorf("Attempt to close connection %s during heal resulted in error: %+v", req.GetConnection().GetId(), err)
@@ -0,0 +1,78 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package journal
+
+import (
+	"context"
+	"time"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/closectx"
+	"github.com/networkservicemesh/sdk/pkg/tools/redact"
+)
+
+// EntryVersion is the version of the Entry schema. Entries without Version are the entries of version 1 having only
+// Time, Source, Destination, Action and Path.
+const EntryVersion = 2
+
+// ActionRequest indicates that the event seen is a connection request.
+// The connection requests may also be a keep alive.
+const ActionRequest = "request"
+
+// ActionClose indicates that the event captured is a connection close.
+const ActionClose = "close"
+
+// Entry is populated and serialized to the journal sinks.
+type Entry struct {
+	Version        int
+	Time           time.Time
+	Source         string
+	Destination    string
+	Action         string
+	ConnectionID   string
+	NetworkService string
+	Endpoint       string
+	Mechanism      string
+	Labels         map[string]string
+	Path           *networkservice.Path
+	// CloseReason is one of closectx reasons, it is set only for ActionClose
+	CloseReason string `json:",omitempty"`
+}
+
+func newEntry(ctx context.Context, action string, conn *networkservice.Connection) *Entry {
+	// Path tokens should not be published
+	path, _ := redact.Message(conn.GetPath()).(*networkservice.Path)
+
+	entry := &Entry{
+		Version:        EntryVersion,
+		Time:           time.Now().UTC(),
+		Source:         conn.GetContext().GetIpContext().GetSrcIpAddr(),
+		Destination:    conn.GetContext().GetIpContext().GetDstIpAddr(),
+		Action:         action,
+		ConnectionID:   conn.GetId(),
+		NetworkService: conn.GetNetworkService(),
+		Endpoint:       conn.GetNetworkServiceEndpointName(),
+		Mechanism:      conn.GetMechanism().GetType(),
+		Labels:         conn.GetLabels(),
+		Path:           path,
+	}
+	if action == ActionClose {
+		entry.CloseReason = closectx.Reason(ctx)
+	}
+	return entry
+}
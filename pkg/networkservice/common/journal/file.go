@@ -0,0 +1,193 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package journal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultMaxFileSize    = 100 << 20
+	defaultMaxFileBackups = 5
+)
+
+type fileSinkOptions struct {
+	maxSize    int64
+	maxBackups int
+}
+
+// FileSinkOption is an option pattern for NewFileSink
+type FileSinkOption func(o *fileSinkOptions)
+
+// WithMaxFileSize sets max size in bytes of the journal file, the file is rotated when it would exceed maxSize
+func WithMaxFileSize(maxSize int64) FileSinkOption {
+	return func(o *fileSinkOptions) {
+		o.maxSize = maxSize
+	}
+}
+
+// WithMaxFileBackups sets number of the rotated journal files to keep: path.1 is the newest one, path.<maxBackups> is
+// the oldest one
+func WithMaxFileBackups(maxBackups int) FileSinkOption {
+	return func(o *fileSinkOptions) {
+		o.maxBackups = maxBackups
+	}
+}
+
+// FileSink is a Sink appending JSON entries to the file, one entry per line
+type FileSink struct {
+	path       string
+	maxSize    int64
+	maxBackups int
+
+	mutex  sync.Mutex
+	file   *os.File
+	size   int64
+	closed bool
+}
+
+// NewFileSink opens the journal file at path for append, FileSink should be closed after use
+func NewFileSink(path string, options ...FileSinkOption) (*FileSink, error) {
+	o := &fileSinkOptions{
+		maxSize:    defaultMaxFileSize,
+		maxBackups: defaultMaxFileBackups,
+	}
+	for _, opt := range options {
+		opt(o)
+	}
+	s := &FileSink{
+		path:       path,
+		maxSize:    o.maxSize,
+		maxBackups: o.maxBackups,
+	}
+	if err := s.open(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Publish appends entry to the file rotating it if needed
+func (s *FileSink) Publish(_ context.Context, entry *Entry) error {
+	js, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	js = append(js, '\n')
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.closed {
+		return errors.Errorf("journal file %s is closed", s.path)
+	}
+	// The file is reopened if it has failed to reopen after a failed rotation
+	if s.file == nil {
+		if err := s.open(); err != nil {
+			return err
+		}
+	}
+	if s.size > 0 && s.size+int64(len(js)) > s.maxSize {
+		if err := s.rotate(); err != nil {
+			return err
+		}
+	}
+	n, err := s.file.Write(js)
+	s.size += int64(n)
+	return err
+}
+
+// Close closes the file
+func (s *FileSink) Close() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+	if s.file == nil {
+		return nil
+	}
+	err := s.file.Close()
+	s.file = nil
+	return err
+}
+
+func (s *FileSink) open() error {
+	file, err := os.OpenFile(s.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return errors.Wrapf(err, "failed to open journal file %s", s.path)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return errors.Wrapf(err, "failed to stat journal file %s", s.path)
+	}
+	s.file = file
+	s.size = info.Size()
+	return nil
+}
+
+// rotate shifts the backups, moves the file to path.1 and opens a new file, s.mutex should be locked. If the rotation
+// fails, the file at path is reopened for append, so the next entries are not lost, and the rotation is retried on the
+// next Publish.
+func (s *FileSink) rotate() error {
+	err := s.file.Close()
+	s.file = nil
+	if err != nil {
+		err = errors.Wrapf(err, "failed to close journal file %s", s.path)
+	} else {
+		err = s.shift()
+	}
+	if err != nil {
+		if openErr := s.open(); openErr != nil {
+			return errors.Wrap(err, openErr.Error())
+		}
+		return err
+	}
+	return s.open()
+}
+
+// shift shifts the backups and moves the file to path.1, the file is removed if no backups are kept
+func (s *FileSink) shift() error {
+	if s.maxBackups <= 0 {
+		if err := os.Remove(s.path); err != nil {
+			return errors.Wrapf(err, "failed to rotate journal file %s", s.path)
+		}
+		return nil
+	}
+	_ = os.Remove(backupPath(s.path, s.maxBackups))
+	for i := s.maxBackups - 1; i > 0; i-- {
+		if err := os.Rename(backupPath(s.path, i), backupPath(s.path, i+1)); err != nil && !os.IsNotExist(err) {
+			return errors.Wrapf(err, "failed to rotate journal file %s", s.path)
+		}
+	}
+	if err := os.Rename(s.path, backupPath(s.path, 1)); err != nil {
+		return errors.Wrapf(err, "failed to rotate journal file %s", s.path)
+	}
+	return nil
+}
+
+func backupPath(path string, index int) string {
+	return fmt.Sprintf("%s.%d", path, index)
+}
@@ -0,0 +1,110 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package journal
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func readEntries(t *testing.T, path string) []*Entry {
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer func() { _ = file.Close() }()
+
+	var entries []*Entry
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		entry := new(Entry)
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), entry))
+		entries = append(entries, entry)
+	}
+	require.NoError(t, scanner.Err())
+	return entries
+}
+
+func TestFileSink_Rotate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "journal")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	path := filepath.Join(dir, "journal.jsonl")
+	entry := &Entry{Version: EntryVersion, Action: ActionRequest, ConnectionID: "id"}
+	js, err := json.Marshal(entry)
+	require.NoError(t, err)
+
+	// Two entries fit into the file
+	sink, err := NewFileSink(path, WithMaxFileSize(int64(2*(len(js)+1))), WithMaxFileBackups(2))
+	require.NoError(t, err)
+
+	for i := 0; i < 7; i++ {
+		require.NoError(t, sink.Publish(context.Background(), entry))
+	}
+	require.NoError(t, sink.Close())
+	require.Error(t, sink.Publish(context.Background(), entry))
+
+	require.Len(t, readEntries(t, path), 1)
+	require.Len(t, readEntries(t, path+".1"), 2)
+	require.Len(t, readEntries(t, path+".2"), 2)
+	_, err = os.Stat(path + ".3")
+	require.True(t, os.IsNotExist(err))
+
+	require.Equal(t, "id", readEntries(t, path)[0].ConnectionID)
+
+	// Reopened file is appended
+	sink, err = NewFileSink(path, WithMaxFileSize(int64(2*(len(js)+1))), WithMaxFileBackups(2))
+	require.NoError(t, err)
+	require.NoError(t, sink.Publish(context.Background(), entry))
+	require.NoError(t, sink.Close())
+	require.Len(t, readEntries(t, path), 2)
+}
+
+func TestFileSink_RotateFailure(t *testing.T) {
+	dir, err := ioutil.TempDir("", "journal")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	path := filepath.Join(dir, "journal.jsonl")
+	entry := &Entry{Version: EntryVersion, Action: ActionRequest, ConnectionID: "id"}
+	js, err := json.Marshal(entry)
+	require.NoError(t, err)
+
+	// The file can't be moved to the backup path taken by a non empty directory
+	require.NoError(t, os.MkdirAll(filepath.Join(path+".1", "dir"), 0700))
+
+	sink, err := NewFileSink(path, WithMaxFileSize(int64(2*(len(js)+1))), WithMaxFileBackups(1))
+	require.NoError(t, err)
+	defer func() { _ = sink.Close() }()
+
+	require.NoError(t, sink.Publish(context.Background(), entry))
+	require.NoError(t, sink.Publish(context.Background(), entry))
+	require.Error(t, sink.Publish(context.Background(), entry))
+	require.Len(t, readEntries(t, path), 2)
+
+	// The rotation is retried once the backup path is freed
+	require.NoError(t, os.RemoveAll(path+".1"))
+	require.NoError(t, sink.Publish(context.Background(), entry))
+	require.Len(t, readEntries(t, path), 1)
+	require.Len(t, readEntries(t, path+".1"), 2)
+}
@@ -0,0 +1,177 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package journal
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"sync/atomic"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+const (
+	journalServiceName  = "networkservicemesh.journal.Journal"
+	watchMethod         = "Watch"
+	defaultWatchBufSize = 100
+)
+
+// watchService is implemented by GRPCSink
+type watchService interface {
+	watch(stream grpc.ServerStream) error
+}
+
+var journalServiceDesc = grpc.ServiceDesc{
+	ServiceName: journalServiceName,
+	HandlerType: (*watchService)(nil),
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    watchMethod,
+			Handler:       watchHandler,
+			ServerStreams: true,
+		},
+	},
+}
+
+func watchHandler(srv interface{}, stream grpc.ServerStream) error {
+	if err := stream.RecvMsg(new(empty.Empty)); err != nil {
+		return err
+	}
+	return srv.(watchService).watch(stream)
+}
+
+// GRPCSink is a Sink streaming JSON entries to the clients of Journal gRPC service, entries published before a client
+// has started watching are not sent to it
+type GRPCSink struct {
+	mutex    sync.Mutex
+	watchers map[chan []byte]struct{}
+	dropped  uint64
+}
+
+// NewGRPCSink creates GRPCSink, it should be registered on grpc.Server with Register
+func NewGRPCSink() *GRPCSink {
+	return &GRPCSink{
+		watchers: make(map[chan []byte]struct{}),
+	}
+}
+
+// Register registers Journal gRPC service of s on server. The service streams the connection details (e.g. network
+// services, endpoints and IP addresses) of all the clients to any watcher and has no authorization of its own, so server
+// should be the API server with transport credentials and an authorization interceptor, and never an unauthenticated
+// one.
+func (s *GRPCSink) Register(server *grpc.Server) {
+	server.RegisterService(&journalServiceDesc, s)
+}
+
+// Publish sends entry to all the watching clients. The entry is dropped for the clients not keeping up with the
+// entries, the drops are counted by Dropped and logged, but are not errors, so slow watchers can't fail the connections
+// in the strict mode.
+func (s *GRPCSink) Publish(ctx context.Context, entry *Entry) error {
+	js, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var dropped int
+	for ch := range s.watchers {
+		select {
+		case ch <- js:
+		default:
+			dropped++
+		}
+	}
+	if dropped > 0 {
+		atomic.AddUint64(&s.dropped, uint64(dropped))
+		log.Entry(ctx).Warnf("journal entry is dropped for %d slow journal watchers", dropped)
+	}
+	return nil
+}
+
+// Dropped returns the number of the entries dropped for the slow watchers
+func (s *GRPCSink) Dropped() uint64 {
+	return atomic.LoadUint64(&s.dropped)
+}
+
+func (s *GRPCSink) watch(stream grpc.ServerStream) error {
+	ch := make(chan []byte, defaultWatchBufSize)
+
+	s.mutex.Lock()
+	s.watchers[ch] = struct{}{}
+	s.mutex.Unlock()
+
+	defer func() {
+		s.mutex.Lock()
+		delete(s.watchers, ch)
+		s.mutex.Unlock()
+	}()
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case js := <-ch:
+			if err := stream.SendMsg(&wrappers.BytesValue{Value: js}); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// WatchClient receives the entries streamed by GRPCSink
+type WatchClient interface {
+	Recv() (*Entry, error)
+}
+
+type watchClient struct {
+	stream grpc.ClientStream
+}
+
+// Watch starts watching the entries published to GRPCSink served on cc, watching stops when ctx is done
+func Watch(ctx context.Context, cc grpc.ClientConnInterface) (WatchClient, error) {
+	stream, err := cc.NewStream(ctx, &journalServiceDesc.Streams[0], "/"+journalServiceName+"/"+watchMethod)
+	if err != nil {
+		return nil, err
+	}
+	if err := stream.SendMsg(new(empty.Empty)); err != nil {
+		return nil, err
+	}
+	if err := stream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return &watchClient{stream: stream}, nil
+}
+
+func (c *watchClient) Recv() (*Entry, error) {
+	msg := new(wrappers.BytesValue)
+	if err := c.stream.RecvMsg(msg); err != nil {
+		return nil, err
+	}
+	entry := new(Entry)
+	if err := json.Unmarshal(msg.GetValue(), entry); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal journal entry")
+	}
+	return entry, nil
+}
@@ -0,0 +1,78 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package journal
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+	"google.golang.org/grpc"
+)
+
+func TestGRPCSink(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	sink := NewGRPCSink()
+	server := grpc.NewServer()
+	sink.Register(server)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() { _ = server.Serve(listener) }()
+	defer server.Stop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	cc, err := grpc.DialContext(ctx, listener.Addr().String(), grpc.WithInsecure(), grpc.WithBlock())
+	require.NoError(t, err)
+	defer func() { _ = cc.Close() }()
+
+	watchCtx, watchCancel := context.WithCancel(ctx)
+	defer watchCancel()
+
+	client, err := Watch(watchCtx, cc)
+	require.NoError(t, err)
+
+	// Wait for the watcher to be registered
+	require.Eventually(t, func() bool {
+		sink.mutex.Lock()
+		defer sink.mutex.Unlock()
+		return len(sink.watchers) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	require.NoError(t, sink.Publish(ctx, &Entry{Version: EntryVersion, Action: ActionClose, ConnectionID: "id"}))
+
+	entry, err := client.Recv()
+	require.NoError(t, err)
+	require.Equal(t, ActionClose, entry.Action)
+	require.Equal(t, "id", entry.ConnectionID)
+}
+
+func TestGRPCSink_SlowWatcher(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	sink := NewGRPCSink()
+	sink.watchers[make(chan []byte)] = struct{}{}
+
+	require.NoError(t, sink.Publish(context.Background(), &Entry{Version: EntryVersion, Action: ActionClose, ConnectionID: "id"}))
+	require.Equal(t, uint64(1), sink.Dropped())
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package history
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const connectionsPath = "/connections"
+
+// Handler returns http.Handler serving JSON answers to the queries to s: "GET /connections?from=<RFC3339>&to=<RFC3339>"
+// returns the connections existed in [from, to] (now is the default to), "GET /connections?ip=<IP>" returns the
+// connections with the source or the destination IP, "GET /connections/<id>" returns the lifecycles of the connection
+// with id
+func Handler(s *Store) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(connectionsPath, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if ip := query.Get("ip"); ip != "" {
+			parsed := parseIP(ip)
+			if parsed == nil {
+				http.Error(w, "invalid ip: "+ip, http.StatusBadRequest)
+				return
+			}
+			writeJSON(w, s.ByIP(parsed))
+			return
+		}
+
+		from, err := time.Parse(time.RFC3339, query.Get("from"))
+		if err != nil {
+			http.Error(w, "invalid from: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		to := time.Now()
+		if query.Get("to") != "" {
+			if to, err = time.Parse(time.RFC3339, query.Get("to")); err != nil {
+				http.Error(w, "invalid to: "+err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+		writeJSON(w, s.Between(from, to))
+	})
+	mux.HandleFunc(connectionsPath+"/", func(w http.ResponseWriter, r *http.Request) {
+		id := strings.TrimPrefix(r.URL.Path, connectionsPath+"/")
+		history := s.History(id)
+		if len(history) == 0 {
+			http.Error(w, "unknown connection: "+id, http.StatusNotFound)
+			return
+		}
+		writeJSON(w, history)
+	})
+	return onlyGet(mux)
+}
+
+func onlyGet(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func writeJSON(w http.ResponseWriter, connections []*Connection) {
+	if connections == nil {
+		connections = []*Connection{}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(connections)
+}
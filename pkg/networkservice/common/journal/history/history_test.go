@@ -0,0 +1,254 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package history_test
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	stand "github.com/nats-io/nats-streaming-server/server"
+	"github.com/nats-io/stan.go"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/journal"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/journal/history"
+	"github.com/networkservicemesh/sdk/pkg/tools/closectx"
+)
+
+var start = time.Date(2020, 7, 1, 10, 0, 0, 0, time.UTC)
+
+func entries() []*journal.Entry {
+	newEntry := func(minutes int, action, id, src string) *journal.Entry {
+		entry := &journal.Entry{
+			Version:        journal.EntryVersion,
+			Time:           start.Add(time.Duration(minutes) * time.Minute),
+			Action:         action,
+			ConnectionID:   id,
+			NetworkService: "ns",
+			Source:         src,
+			Destination:    "10.0.0.2/32",
+		}
+		if action == journal.ActionClose {
+			entry.CloseReason = closectx.ReasonTimeout
+		}
+		return entry
+	}
+	return []*journal.Entry{
+		newEntry(0, journal.ActionRequest, "a", "10.0.0.1/32"),
+		newEntry(1, journal.ActionRequest, "a", "10.0.0.1/32"),
+		newEntry(2, journal.ActionClose, "a", "10.0.0.1/32"),
+		newEntry(3, journal.ActionRequest, "b", "10.0.0.3/32"),
+		newEntry(4, journal.ActionRequest, "a", "10.0.0.5/32"),
+		newEntry(5, journal.ActionClose, "c", "10.0.0.7/32"),
+		newEntry(6, journal.ActionRequest, "b", "10.0.0.4/32"),
+	}
+}
+
+func checkStore(t *testing.T, store *history.Store) {
+	a := store.History("a")
+	require.Len(t, a, 2)
+	require.Equal(t, start, a[0].Start)
+	require.Equal(t, start.Add(time.Minute), a[0].LastSeen)
+	require.Equal(t, start.Add(2*time.Minute), a[0].End)
+	require.Equal(t, closectx.ReasonTimeout, a[0].CloseReason)
+	require.Len(t, a[0].Entries, 3)
+	require.False(t, a[1].IsClosed())
+	require.Equal(t, "10.0.0.5/32", a[1].Source)
+
+	require.Empty(t, store.History("c"))
+
+	between := store.Between(start.Add(150*time.Second), start.Add(210*time.Second))
+	require.Len(t, between, 1)
+	require.Equal(t, "b", between[0].ID)
+
+	// Not closed connections expire in DefaultExpiry after the last Request
+	require.Len(t, store.Between(start.Add(15*time.Minute), start.Add(time.Hour)), 1)
+	require.Empty(t, store.Between(start.Add(time.Hour), start.Add(2*time.Hour)))
+	require.Len(t, store.Between(start.Add(-time.Hour), start), 1)
+
+	byIP := store.ByIP(net.ParseIP("10.0.0.1"))
+	require.Len(t, byIP, 1)
+	require.Equal(t, "a", byIP[0].ID)
+	require.Len(t, store.ByIP(net.ParseIP("10.0.0.2")), 3)
+
+	// The source of b has been changed by the last Request
+	byIP = store.ByIP(net.ParseIP("10.0.0.3"))
+	require.Len(t, byIP, 1)
+	require.Equal(t, "b", byIP[0].ID)
+	require.Equal(t, "10.0.0.4/32", byIP[0].Source)
+}
+
+func TestStore(t *testing.T) {
+	store := history.NewStore()
+	for _, entry := range entries() {
+		store.Add(entry)
+	}
+	checkStore(t, store)
+}
+
+func TestStore_ReplayFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "history")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	path := filepath.Join(dir, "journal.jsonl")
+	sink, err := journal.NewFileSink(path, journal.WithMaxFileSize(300), journal.WithMaxFileBackups(10))
+	require.NoError(t, err)
+	for _, entry := range entries() {
+		require.NoError(t, sink.Publish(context.Background(), entry))
+	}
+	require.NoError(t, sink.Close())
+
+	_, err = os.Stat(path + ".1")
+	require.NoError(t, err, "journal file should be rotated")
+
+	store := history.NewStore()
+	require.NoError(t, store.ReplayFile(path, 10))
+	checkStore(t, store)
+}
+
+func TestStore_Replay_InvalidLine(t *testing.T) {
+	lines := new(strings.Builder)
+	for _, entry := range entries() {
+		js, err := json.Marshal(entry)
+		require.NoError(t, err)
+		lines.Write(js)
+		lines.WriteString("\n")
+		// Entry truncated by a crash
+		lines.Write(js[:len(js)/2])
+		lines.WriteString("\n")
+	}
+
+	store := history.NewStore()
+	require.NoError(t, store.Replay(strings.NewReader(lines.String())))
+	checkStore(t, store)
+}
+
+func TestStore_Limits(t *testing.T) {
+	store := history.NewStore(history.WithMaxEntries(2), history.WithRetention(time.Hour))
+	for _, entry := range entries() {
+		store.Add(entry)
+	}
+	a := store.History("a")
+	require.Len(t, a, 2)
+	require.Len(t, a[0].Entries, 2)
+	require.Equal(t, start.Add(time.Minute), a[0].Entries[0].Time)
+
+	store.Add(&journal.Entry{
+		Version:      journal.EntryVersion,
+		Time:         start.Add(2 * time.Hour),
+		Action:       journal.ActionRequest,
+		ConnectionID: "d",
+	})
+	require.Empty(t, store.History("a"))
+	require.Empty(t, store.History("b"))
+	require.Len(t, store.History("d"), 1)
+}
+
+func TestStore_PathExpiry(t *testing.T) {
+	expires, err := ptypes.TimestampProto(start.Add(time.Hour))
+	require.NoError(t, err)
+
+	store := history.NewStore()
+	store.Add(&journal.Entry{
+		Version:      journal.EntryVersion,
+		Time:         start,
+		Action:       journal.ActionRequest,
+		ConnectionID: "a",
+		Path: &networkservice.Path{
+			PathSegments: []*networkservice.PathSegment{{Expires: expires}},
+		},
+	})
+	require.Len(t, store.Between(start.Add(30*time.Minute), start.Add(40*time.Minute)), 1)
+	require.Empty(t, store.Between(start.Add(2*time.Hour), start.Add(3*time.Hour)))
+}
+
+func TestStore_SubscribeNATS(t *testing.T) {
+	streamingServer, err := stand.RunServer("test_history")
+	require.NoError(t, err)
+	defer streamingServer.Shutdown()
+
+	conn, err := stan.Connect(streamingServer.ClusterID(), "publisher")
+	require.NoError(t, err)
+	defer func() { _ = conn.Close() }()
+
+	sink, err := journal.NewNATSSink("journal", conn)
+	require.NoError(t, err)
+	for _, entry := range entries() {
+		require.NoError(t, sink.Publish(context.Background(), entry))
+	}
+
+	store := history.NewStore()
+	sub, err := store.SubscribeNATS("journal", conn)
+	require.NoError(t, err)
+	defer func() { _ = sub.Close() }()
+
+	require.Eventually(t, func() bool {
+		b := store.History("b")
+		return len(b) == 1 && len(b[0].Entries) == 2 && len(store.History("a")) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	checkStore(t, store)
+}
+
+func TestHandler(t *testing.T) {
+	store := history.NewStore()
+	for _, entry := range entries() {
+		store.Add(entry)
+	}
+	server := httptest.NewServer(history.Handler(store))
+	defer server.Close()
+
+	get := func(path string, expectedCode int) []*history.Connection {
+		resp, err := http.Get(server.URL + path)
+		require.NoError(t, err)
+		defer func() { _ = resp.Body.Close() }()
+
+		require.Equal(t, expectedCode, resp.StatusCode)
+		if expectedCode != http.StatusOK {
+			return nil
+		}
+		var connections []*history.Connection
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&connections))
+		return connections
+	}
+
+	require.Len(t, get("/connections/a", http.StatusOK), 2)
+	get("/connections/unknown", http.StatusNotFound)
+
+	connections := get("/connections?from=2020-07-01T10:02:30Z&to=2020-07-01T10:03:30Z", http.StatusOK)
+	require.Len(t, connections, 1)
+	require.Equal(t, "b", connections[0].ID)
+	require.Len(t, get("/connections?from=2020-07-01T09:00:00Z", http.StatusOK), 3)
+	get("/connections?from=yesterday", http.StatusBadRequest)
+
+	connections = get("/connections?ip=10.0.0.3", http.StatusOK)
+	require.Len(t, connections, 1)
+	require.Equal(t, "b", connections[0].ID)
+	require.Empty(t, get("/connections?ip=10.0.0.9", http.StatusOK))
+	get("/connections?ip=invalid", http.StatusBadRequest)
+}
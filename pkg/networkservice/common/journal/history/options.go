@@ -0,0 +1,62 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package history
+
+import (
+	"time"
+)
+
+const (
+	// DefaultExpiry is the default time a not closed connection exists after its last Request if the Request path has
+	// no expiration time
+	DefaultExpiry = 10 * time.Minute
+	// DefaultMaxEntries is the default max number of the entries kept for a connection lifecycle
+	DefaultMaxEntries = 1000
+)
+
+type storeOptions struct {
+	expiry     time.Duration
+	maxEntries int
+	retention  time.Duration
+}
+
+// Option is an option pattern for NewStore
+type Option func(o *storeOptions)
+
+// WithExpiry sets the time a not closed connection exists after its last Request if the Request path has no
+// expiration time, DefaultExpiry is used by default
+func WithExpiry(expiry time.Duration) Option {
+	return func(o *storeOptions) {
+		o.expiry = expiry
+	}
+}
+
+// WithMaxEntries sets the max number of the entries kept for a connection lifecycle, the oldest entries are dropped
+// first. DefaultMaxEntries is used by default.
+func WithMaxEntries(maxEntries int) Option {
+	return func(o *storeOptions) {
+		o.maxEntries = maxEntries
+	}
+}
+
+// WithRetention makes the store drop the connection lifecycles ended or expired longer than retention before the
+// latest added entry, the lifecycles are kept forever by default
+func WithRetention(retention time.Duration) Option {
+	return func(o *storeOptions) {
+		o.retention = retention
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package history
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	stan "github.com/nats-io/stan.go"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/journal"
+)
+
+const maxLineSize = 1 << 20
+
+// Replay adds the JSON lines entries read from r to s, the invalid lines (e.g. the line truncated by a crash) are
+// logged and skipped
+func (s *Store) Replay(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, maxLineSize)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		entry := new(journal.Entry)
+		if err := json.Unmarshal(scanner.Bytes(), entry); err != nil {
+			logrus.Errorf("failed to unmarshal journal entry at line %d: %v", line, err)
+			continue
+		}
+		s.Add(entry)
+	}
+	return scanner.Err()
+}
+
+// ReplayFile adds the entries of the journal file sink at path to s, the rotated backups path.<maxBackups>, ...,
+// path.1 are replayed first
+func (s *Store) ReplayFile(path string, maxBackups int) error {
+	paths := []string{path}
+	for i := 1; i <= maxBackups; i++ {
+		paths = append([]string{fmt.Sprintf("%s.%d", path, i)}, paths...)
+	}
+	for _, p := range paths {
+		if err := s.replayFile(p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Store) replayFile(path string) error {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to open journal file %s", path)
+	}
+	defer func() { _ = file.Close() }()
+
+	return errors.Wrapf(s.Replay(file), "failed to replay journal file %s", path)
+}
+
+// SubscribeNATS adds all the entries available in the journalID subject and the following ones to s until the
+// returned subscription is closed
+func (s *Store) SubscribeNATS(journalID string, stanConn stan.Conn) (stan.Subscription, error) {
+	return stanConn.Subscribe(journalID, func(msg *stan.Msg) {
+		entry := new(journal.Entry)
+		if err := json.Unmarshal(msg.Data, entry); err != nil {
+			logrus.Errorf("failed to unmarshal journal entry %d: %v", msg.Sequence, err)
+			return
+		}
+		s.Add(entry)
+	}, stan.DeliverAllAvailable())
+}
+
+// Consume adds the entries received from client to s until ctx is done or the stream fails
+func (s *Store) Consume(ctx context.Context, client journal.WatchClient) error {
+	for {
+		entry, err := client.Recv()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		s.Add(entry)
+	}
+}
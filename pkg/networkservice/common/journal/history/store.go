@@ -0,0 +1,287 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package history rebuilds connection lifecycles from the journal entries and answers the forensics queries: which
+// connections existed in a time range, what is the history of a connection and which connections have used an IP
+package history
+
+import (
+	"net"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/journal"
+)
+
+// Connection is a lifecycle of a connection: from the first Request to the Close
+type Connection struct {
+	ID             string
+	NetworkService string
+	Endpoint       string
+	Mechanism      string
+	Labels         map[string]string
+	Source         string
+	Destination    string
+	// Addresses are all the source and the destination addresses the connection has had
+	Addresses []string
+	Start     time.Time
+	LastSeen  time.Time
+	// Expires is the time the connection not closed yet stops existing if it is not requested again
+	Expires time.Time
+	// End is zero for the connections not closed yet
+	End         time.Time
+	CloseReason string
+	// Entries are the last entries of the connection, their number is limited by WithMaxEntries
+	Entries []*journal.Entry
+}
+
+// IsClosed returns true if the connection has been closed
+func (c *Connection) IsClosed() bool {
+	return !c.End.IsZero()
+}
+
+// end returns the time the connection has stopped or will stop existing
+func (c *Connection) end() time.Time {
+	if c.IsClosed() {
+		return c.End
+	}
+	return c.Expires
+}
+
+const pruneInterval = time.Minute
+
+// Store is an in-memory index of the connection lifecycles
+type Store struct {
+	opts        storeOptions
+	mutex       sync.RWMutex
+	connections map[string][]*Connection
+	latest      time.Time
+	nextPrune   time.Time
+}
+
+// NewStore creates an empty Store
+func NewStore(options ...Option) *Store {
+	s := &Store{
+		opts: storeOptions{
+			expiry:     DefaultExpiry,
+			maxEntries: DefaultMaxEntries,
+		},
+		connections: make(map[string][]*Connection),
+	}
+	for _, o := range options {
+		o(&s.opts)
+	}
+	return s
+}
+
+// Add adds entry to the lifecycle of its connection, a Request after the Close starts a new lifecycle. Entries of
+// version 1 have no connection ID, the ID of the first path segment is used for them and their Closes are skipped.
+func (s *Store) Add(entry *journal.Entry) {
+	id := entry.ConnectionID
+	if id == "" && len(entry.Path.GetPathSegments()) > 0 {
+		id = entry.Path.GetPathSegments()[0].GetId()
+	}
+	if id == "" {
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	lifecycles := s.connections[id]
+	var conn *Connection
+	if len(lifecycles) > 0 && !lifecycles[len(lifecycles)-1].IsClosed() {
+		conn = lifecycles[len(lifecycles)-1]
+	}
+
+	switch entry.Action {
+	case journal.ActionRequest:
+		if conn == nil {
+			conn = &Connection{ID: id, Start: entry.Time}
+			s.connections[id] = append(lifecycles, conn)
+		}
+		conn.update(entry)
+		conn.LastSeen = entry.Time
+		conn.Expires = expirationTime(entry, s.opts.expiry)
+	case journal.ActionClose:
+		if conn == nil {
+			return
+		}
+		conn.update(entry)
+		conn.End = entry.Time
+		conn.CloseReason = entry.CloseReason
+	default:
+		return
+	}
+	conn.Entries = append(conn.Entries, entry)
+	if s.opts.maxEntries > 0 && len(conn.Entries) > s.opts.maxEntries {
+		n := copy(conn.Entries, conn.Entries[len(conn.Entries)-s.opts.maxEntries:])
+		conn.Entries = conn.Entries[:n]
+	}
+	s.prune(entry.Time)
+}
+
+// expirationTime returns the earliest expiration time of the entry path segments, or the entry time + expiry if the
+// path has no expiration time
+func expirationTime(entry *journal.Entry, expiry time.Duration) (rv time.Time) {
+	for _, segment := range entry.Path.GetPathSegments() {
+		if segment.GetExpires() == nil {
+			continue
+		}
+		if expires, err := ptypes.Timestamp(segment.GetExpires()); err == nil && (rv.IsZero() || expires.Before(rv)) {
+			rv = expires
+		}
+	}
+	if rv.IsZero() {
+		rv = entry.Time.Add(expiry)
+	}
+	return rv
+}
+
+// prune drops the lifecycles ended longer than the retention before now, s.mutex should be locked
+func (s *Store) prune(now time.Time) {
+	if s.opts.retention <= 0 || !now.After(s.latest) {
+		return
+	}
+	s.latest = now
+	if now.Before(s.nextPrune) {
+		return
+	}
+	s.nextPrune = now.Add(pruneInterval)
+
+	deadline := now.Add(-s.opts.retention)
+	for id, lifecycles := range s.connections {
+		kept := lifecycles[:0]
+		for _, conn := range lifecycles {
+			if !conn.end().Before(deadline) {
+				kept = append(kept, conn)
+			}
+		}
+		if len(kept) == 0 {
+			delete(s.connections, id)
+			continue
+		}
+		s.connections[id] = kept
+	}
+}
+
+// update updates the connection properties with non-empty properties of entry
+func (c *Connection) update(entry *journal.Entry) {
+	for _, field := range []struct {
+		dst *string
+		src string
+	}{
+		{&c.NetworkService, entry.NetworkService},
+		{&c.Endpoint, entry.Endpoint},
+		{&c.Mechanism, entry.Mechanism},
+		{&c.Source, entry.Source},
+		{&c.Destination, entry.Destination},
+	} {
+		if field.src != "" {
+			*field.dst = field.src
+		}
+	}
+	if entry.Labels != nil {
+		c.Labels = entry.Labels
+	}
+	for _, addr := range []string{entry.Source, entry.Destination} {
+		if addr != "" && !contains(c.Addresses, addr) {
+			c.Addresses = append(c.Addresses, addr)
+		}
+	}
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// clone returns a copy of c not modified by the following Store.Add, the Store mutex should be locked
+func (c *Connection) clone() *Connection {
+	rv := *c
+	rv.Addresses = append([]string(nil), c.Addresses...)
+	rv.Entries = append([]*journal.Entry(nil), c.Entries...)
+	return &rv
+}
+
+// History returns the lifecycles of the connection with id ordered by Start
+func (s *Store) History(id string) []*Connection {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	var rv []*Connection
+	for _, conn := range s.connections[id] {
+		rv = append(rv, conn.clone())
+	}
+	return rv
+}
+
+// Between returns the connections existed in [from, to], not closed connections are considered existing until they
+// expire
+func (s *Store) Between(from, to time.Time) []*Connection {
+	return s.find(func(conn *Connection) bool {
+		return !conn.Start.After(to) && !conn.end().Before(from)
+	})
+}
+
+// ByIP returns the connections having had ip as the source or the destination address
+func (s *Store) ByIP(ip net.IP) []*Connection {
+	return s.find(func(conn *Connection) bool {
+		for _, addr := range conn.Addresses {
+			if ip.Equal(parseIP(addr)) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+func (s *Store) find(match func(conn *Connection) bool) []*Connection {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	var rv []*Connection
+	for _, lifecycles := range s.connections {
+		for _, conn := range lifecycles {
+			if match(conn) {
+				rv = append(rv, conn.clone())
+			}
+		}
+	}
+	sort.Slice(rv, func(i, j int) bool {
+		if rv[i].Start.Equal(rv[j].Start) {
+			return rv[i].ID < rv[j].ID
+		}
+		return rv[i].Start.Before(rv[j].Start)
+	})
+	return rv
+}
+
+// parseIP parses IP address with an optional prefix length
+func parseIP(s string) net.IP {
+	if i := strings.IndexByte(s, '/'); i >= 0 {
+		s = s[:i]
+	}
+	return net.ParseIP(s)
+}
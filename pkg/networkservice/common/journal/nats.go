@@ -0,0 +1,50 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package journal
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	stan "github.com/nats-io/stan.go"
+	"github.com/pkg/errors"
+)
+
+type natsSink struct {
+	journalID string
+	nats      stan.Conn
+}
+
+// NewNATSSink creates a Sink publishing JSON entries to the journalID subject using provided streaming NATS connection
+func NewNATSSink(journalID string, stanConn stan.Conn) (Sink, error) {
+	if strings.TrimSpace(journalID) == "" {
+		return nil, errors.New("journal id is nil")
+	}
+	return &natsSink{
+		journalID: journalID,
+		nats:      stanConn,
+	}, nil
+}
+
+func (s *natsSink) Publish(_ context.Context, entry *Entry) error {
+	js, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	return s.nats.Publish(s.journalID, js)
+}
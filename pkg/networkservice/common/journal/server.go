@@ -14,44 +14,46 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-// Package journal emits IP and PATH related event messages to NATS.
-// The journal may be used for healing IPAM and/or auditing
-// connection activity.
+// Package journal emits connection related event messages to the sinks: NATS, rotating JSON lines files or gRPC
+// streams. The journal may be used for healing IPAM and/or auditing connection activity.
 package journal
 
 import (
 	"context"
-	"encoding/json"
-	"errors"
-	"strings"
-	"time"
 
 	"github.com/golang/protobuf/ptypes/empty"
 	stan "github.com/nats-io/stan.go"
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
 
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/trace"
 )
 
-// ActionRequest indicates that the event seen is a connection request.
-// The connection requests may also be a keep alive.
-const ActionRequest = "request"
-
-// ActionClose indicates that the event captured is a connection close.
-const ActionClose = "close"
+type journalServer struct {
+	sink   Sink
+	strict bool
+}
 
-// Entry is populated and serialized to NATS.
-type Entry struct {
-	Time        time.Time
-	Source      string
-	Destination string
-	Action      string
-	Path        *networkservice.Path
+// NewServer creates a new journaling server with the name journalID using provided streaming NATS connection, requests
+// fail if their entries cannot be published (see WithStrict)
+func NewServer(journalID string, stanConn stan.Conn) (networkservice.NetworkServiceServer, error) {
+	sink, err := NewNATSSink(journalID, stanConn)
+	if err != nil {
+		return nil, err
+	}
+	return NewSinkServer(sink, WithStrict()), nil
 }
 
-type journalServer struct {
-	journalID string
-	nats      stan.Conn
+// NewSinkServer creates a new journaling server publishing the entries to sink
+func NewSinkServer(sink Sink, options ...Option) networkservice.NetworkServiceServer {
+	o := &serverOptions{}
+	for _, opt := range options {
+		opt(o)
+	}
+	return &journalServer{
+		sink:   sink,
+		strict: o.strict,
+	}
 }
 
 func (srv *journalServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
@@ -60,58 +62,38 @@ func (srv *journalServer) Request(ctx context.Context, request *networkservice.N
 		return conn, err
 	}
 
-	src := conn.GetContext().GetIpContext().GetSrcIpAddr()
-	dst := conn.GetContext().GetIpContext().GetDstIpAddr()
-	path := conn.GetPath()
-
-	entry := Entry{
-		Time:        time.Now().UTC(),
-		Source:      src,
-		Destination: dst,
-		Action:      ActionRequest,
-		Path:        path,
+	if err := srv.publish(ctx, newEntry(ctx, ActionRequest, conn)); err != nil {
+		// The connection cannot be left established without the journal entry in the strict mode
+		if _, closeErr := next.Server(ctx).Close(ctx, conn); closeErr != nil {
+			trace.Log(ctx).Errorf("failed to close connection %s: %+v", conn.GetId(), closeErr)
+		}
+		return nil, err
 	}
-
-	err = srv.publish(&entry)
-
-	return conn, err
+	return conn, nil
 }
-func (srv *journalServer) Close(ctx context.Context, connection *networkservice.Connection) (*empty.Empty, error) {
-	src := connection.GetContext().GetIpContext().GetSrcIpAddr()
-	dst := connection.GetContext().GetIpContext().GetDstIpAddr()
-	entry := Entry{
-		Time:        time.Now().UTC(),
-		Source:      src,
-		Destination: dst,
-		Action:      ActionClose,
-	}
-
-	// squash error if present
-	_ = srv.publish(&entry)
 
-	return next.Server(ctx).Close(ctx, connection)
-}
+func (srv *journalServer) Close(ctx context.Context, connection *networkservice.Connection) (*empty.Empty, error) {
+	publishErr := srv.publish(ctx, newEntry(ctx, ActionClose, connection))
 
-func (srv *journalServer) publish(entry *Entry) error {
-	js, err := json.Marshal(entry)
+	rv, err := next.Server(ctx).Close(ctx, connection)
 	if err != nil {
-		return err
+		return nil, err
 	}
-
-	err = srv.nats.Publish(srv.journalID, js)
-	if err != nil {
-		return err
+	if publishErr != nil {
+		return nil, publishErr
 	}
-	return nil
+	return rv, nil
 }
 
-// NewServer creates a new journaling server with the name journalID using provided streaming NATS connection
-func NewServer(journalID string, stanConn stan.Conn) (networkservice.NetworkServiceServer, error) {
-	if strings.TrimSpace(journalID) == "" {
-		return nil, errors.New("journal id is nil")
+// publish publishes entry to the sink, the error is returned only in the strict mode
+func (srv *journalServer) publish(ctx context.Context, entry *Entry) error {
+	err := srv.sink.Publish(ctx, entry)
+	if err == nil {
+		return nil
 	}
-	return &journalServer{
-		journalID: journalID,
-		nats:      stanConn,
-	}, nil
+	trace.Log(ctx).Errorf("failed to publish journal entry for connection %s: %+v", entry.ConnectionID, err)
+	if srv.strict {
+		return err
+	}
+	return nil
 }
@@ -18,6 +18,7 @@ package journal
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"sync"
 	"testing"
 	"time"
@@ -26,6 +27,9 @@ import (
 	"github.com/nats-io/stan.go"
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/closectx"
+	"github.com/networkservicemesh/sdk/pkg/tools/redact"
 )
 
 func TestConnect(t *testing.T) {
@@ -45,18 +49,23 @@ func TestConnect(t *testing.T) {
 		_ = testConn.Close()
 	}()
 
-	srv, err := NewServer("foo", conn)
+	sink, err := NewNATSSink("foo", conn)
 	assert.NoError(t, err)
+	srv := NewSinkServer(sink)
 
 	req := &networkservice.NetworkServiceRequest{
 		Connection: &networkservice.Connection{
+			Id:             "id",
+			NetworkService: "ns",
 			Context: &networkservice.ConnectionContext{
 				IpContext: &networkservice.IPContext{
 					SrcIpAddr: "10.0.0.1/32",
 					DstIpAddr: "10.0.0.2/32",
 				},
 			},
-			Path: &networkservice.Path{},
+			Path: &networkservice.Path{
+				PathSegments: []*networkservice.PathSegment{{Name: "nsc", Token: "token"}},
+			},
 		},
 	}
 
@@ -75,7 +84,11 @@ func TestConnect(t *testing.T) {
 		assert.Equal(t, "10.0.0.1/32", entry.Source)
 		assert.Equal(t, "10.0.0.2/32", entry.Destination)
 		assert.Equal(t, ActionRequest, entry.Action)
+		assert.Equal(t, EntryVersion, entry.Version)
+		assert.Equal(t, "id", entry.ConnectionID)
+		assert.Equal(t, "ns", entry.NetworkService)
 		assert.NotNil(t, entry.Path)
+		assert.Equal(t, redact.Redacted, entry.Path.GetPathSegments()[0].GetToken())
 
 		wg.Done()
 	})
@@ -89,3 +102,26 @@ func TestConnect(t *testing.T) {
 
 	wg.Wait()
 }
+
+func TestStrict(t *testing.T) {
+	var entries []*Entry
+	failing := SinkFunc(func(_ context.Context, entry *Entry) error {
+		entries = append(entries, entry)
+		return errors.New("publish error")
+	})
+	conn := &networkservice.Connection{Id: "id"}
+
+	_, err := NewSinkServer(failing).Request(context.Background(), &networkservice.NetworkServiceRequest{Connection: conn})
+	assert.NoError(t, err)
+
+	_, err = NewSinkServer(failing, WithStrict()).Request(context.Background(), &networkservice.NetworkServiceRequest{Connection: conn})
+	assert.Error(t, err)
+
+	_, err = NewSinkServer(failing, WithStrict()).Close(closectx.WithReason(context.Background(), closectx.ReasonTimeout), conn)
+	assert.Error(t, err)
+
+	assert.Len(t, entries, 3)
+	assert.Equal(t, ActionClose, entries[2].Action)
+	assert.Equal(t, closectx.ReasonTimeout, entries[2].CloseReason)
+	assert.Empty(t, entries[0].CloseReason)
+}
@@ -0,0 +1,51 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package journal
+
+import (
+	"context"
+)
+
+// Sink publishes the journal entries
+type Sink interface {
+	Publish(ctx context.Context, entry *Entry) error
+}
+
+// SinkFunc is a function adapter for Sink
+type SinkFunc func(ctx context.Context, entry *Entry) error
+
+// Publish calls f(ctx, entry)
+func (f SinkFunc) Publish(ctx context.Context, entry *Entry) error {
+	return f(ctx, entry)
+}
+
+type multiSink []Sink
+
+// NewMultiSink creates a Sink publishing the entries to all the sinks, the first error is returned after all the sinks
+// are called
+func NewMultiSink(sinks ...Sink) Sink {
+	return multiSink(sinks)
+}
+
+func (s multiSink) Publish(ctx context.Context, entry *Entry) (err error) {
+	for _, sink := range s {
+		if publishErr := sink.Publish(ctx, entry); publishErr != nil && err == nil {
+			err = publishErr
+		}
+	}
+	return err
+}
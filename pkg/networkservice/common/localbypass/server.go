@@ -79,3 +79,15 @@ func (l *localBypassServer) LoadOrStore(name string, u *url.URL) (interface{}, b
 func (l *localBypassServer) Delete(name string) {
 	l.sockets.Delete(name)
 }
+
+// State - returns the map of the local Endpoint names to their file socket URLs
+func (l *localBypassServer) State() interface{} {
+	sockets := make(map[string]string)
+	l.sockets.Range(func(key, value interface{}) bool {
+		if u, ok := value.(*url.URL); ok && u != nil {
+			sockets[key.(string)] = u.String()
+		}
+		return true
+	})
+	return sockets
+}
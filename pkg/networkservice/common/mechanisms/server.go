@@ -23,11 +23,11 @@ import (
 
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
-	"github.com/pkg/errors"
 
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/chain"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/trace"
+	"github.com/networkservicemesh/sdk/pkg/tools/nsmerrors"
 )
 
 type mechanismsServer struct {
@@ -63,7 +63,8 @@ func (m *mechanismsServer) Request(ctx context.Context, request *networkservice.
 			request.Connection = conn
 			return next.Server(ctx).Request(ctx, request)
 		}
-		return nil, errors.Errorf("Unsupported Mechanism: %+v", request.GetConnection().GetMechanism())
+		return nil, nsmerrors.New(nsmerrors.ReasonUnsupportedMechanism, "Unsupported Mechanism: %+v", request.GetConnection().GetMechanism()).
+			WithMetadata("mechanism", request.GetConnection().GetMechanism().GetType())
 	}
 	for _, mechanism := range request.GetMechanismPreferences() {
 		srv, ok := m.mechanisms[mechanism.GetType()]
@@ -73,7 +74,7 @@ func (m *mechanismsServer) Request(ctx context.Context, request *networkservice.
 			return srv.Request(ctx, req)
 		}
 	}
-	return nil, errors.Errorf("Cannot support any of the requested Mechanisms: %+v", request.GetMechanismPreferences())
+	return nil, nsmerrors.New(nsmerrors.ReasonUnsupportedMechanism, "Cannot support any of the requested Mechanisms: %+v", request.GetMechanismPreferences())
 }
 
 func (m *mechanismsServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
@@ -82,5 +83,6 @@ func (m *mechanismsServer) Close(ctx context.Context, conn *networkservice.Conne
 		srv = trace.NewNetworkServiceServer(srv)
 		return srv.Close(ctx, conn)
 	}
-	return nil, errors.Errorf("Cannot support any of the requested Mechanism: %+v", conn.GetMechanism())
+	return nil, nsmerrors.New(nsmerrors.ReasonUnsupportedMechanism, "Cannot support any of the requested Mechanism: %+v", conn.GetMechanism()).
+		WithMetadata("mechanism", conn.GetMechanism().GetType())
 }
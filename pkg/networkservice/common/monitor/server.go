@@ -26,6 +26,7 @@ import (
 
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/trace"
+	"github.com/networkservicemesh/sdk/pkg/tools/redact"
 	"github.com/networkservicemesh/sdk/pkg/tools/serialize"
 
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
@@ -111,6 +112,17 @@ func (m *monitorServer) Close(ctx context.Context, conn *networkservice.Connecti
 	return next.Server(ctx).Close(ctx, conn)
 }
 
+// State - returns redacted copies of the active connections by ID
+func (m *monitorServer) State() interface{} {
+	connections := make(map[string]*networkservice.Connection)
+	<-m.executor.AsyncExec(func() {
+		for id, conn := range m.connections {
+			connections[id] = redact.Message(conn).(*networkservice.Connection)
+		}
+	})
+	return connections
+}
+
 // send - perform a send to clients.
 func (m *monitorServer) send(ctx context.Context, event *networkservice.ConnectionEvent) (err error) {
 	newMonitors := []networkservice.MonitorConnection_MonitorConnectionsServer{}
@@ -21,13 +21,13 @@ import (
 	"net/url"
 
 	"github.com/golang/protobuf/ptypes/empty"
-	"github.com/pkg/errors"
 
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
 
 	"github.com/networkservicemesh/sdk/pkg/networkservice/common/clienturl"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/common/discover"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/nsmerrors"
 )
 
 type selectEndpointServer struct {
@@ -64,13 +64,15 @@ func (s *selectEndpointServer) withClientURL(ctx context.Context, conn *networks
 		candidates := discover.Candidates(ctx)
 		endpoint := s.selector.selectEndpoint(candidates.NetworkService, candidates.Endpoints)
 		if endpoint == nil {
-			return nil, errors.Errorf("failed to find endpoint for Network Service: %v %v", candidates.NetworkService, candidates.Endpoints)
+			return nil, nsmerrors.New(nsmerrors.ReasonNoEndpoint, "failed to find endpoint for Network Service: %v %v", candidates.NetworkService, candidates.Endpoints).
+				WithMetadata("network_service", conn.GetNetworkService())
 		}
 		conn.NetworkServiceEndpointName = endpoint.GetName()
 		urlString := endpoint.Url
 		u, err := url.Parse(urlString)
 		if err != nil {
-			return nil, errors.WithStack(err)
+			return nil, nsmerrors.Wrap(err, nsmerrors.ReasonInvalidEndpointURL, "invalid URL of endpoint %s", endpoint.GetName()).
+				WithMetadata("endpoint", endpoint.GetName())
 		}
 		ctx = clienturl.WithClientURL(ctx, u)
 		return ctx, nil
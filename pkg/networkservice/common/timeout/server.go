@@ -27,7 +27,9 @@ import (
 
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/trace"
+	"github.com/networkservicemesh/sdk/pkg/tools/closectx"
 	"github.com/networkservicemesh/sdk/pkg/tools/extend"
+	"github.com/networkservicemesh/sdk/pkg/tools/nsmerrors"
 	"github.com/networkservicemesh/sdk/pkg/tools/serialize"
 )
 
@@ -85,11 +87,11 @@ func (t *timeoutServer) Close(ctx context.Context, conn *networkservice.Connecti
 func (t *timeoutServer) createTimer(ctx context.Context, request *networkservice.NetworkServiceRequest) (*time.Timer, error) {
 	expireTime, err := ptypes.Timestamp(request.GetConnection().GetPath().GetPathSegments()[request.GetConnection().GetPath().GetIndex()].GetExpires())
 	if err != nil {
-		return nil, err
+		return nil, nsmerrors.Wrap(err, nsmerrors.ReasonInvalidExpiration, "invalid expiration time of connection %s", request.GetConnection().GetId())
 	}
 	duration := time.Until(expireTime)
 	return time.AfterFunc(duration, func() {
-		newCtx := extend.WithValuesFromContext(context.Background(), ctx)
+		newCtx := closectx.WithReason(extend.WithValuesFromContext(context.Background(), ctx), closectx.ReasonTimeout)
 		if _, err := (*t.onTimeout).Close(newCtx, request.GetConnection()); err != nil {
 			trace.Log(newCtx).Errorf("Error attempting to close timed out connection: %s: %+v", request.GetConnection().GetId(), err)
 		}
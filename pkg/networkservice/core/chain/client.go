@@ -19,11 +19,27 @@ package chain
 import (
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
 
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/metrics"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/profile"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/trace"
+	toolsmetrics "github.com/networkservicemesh/sdk/pkg/tools/metrics"
+	"github.com/networkservicemesh/sdk/pkg/tools/typeutils"
 )
 
-// NewNetworkServiceClient - chains together a list of networkservice.NetworkServiceClient with tracing
+// NewNetworkServiceClient - chains together a list of networkservice.NetworkServiceClients with tracing, with metrics if
+// toolsmetrics.IsEnabled() and with self-time profiling if profile.IsEnabled()
 func NewNetworkServiceClient(clients ...networkservice.NetworkServiceClient) networkservice.NetworkServiceClient {
-	return next.NewWrappedNetworkServiceClient(trace.NewNetworkServiceClient, clients...)
+	metricsEnabled, profileEnabled := toolsmetrics.IsEnabled(), profile.IsEnabled()
+	return next.NewWrappedNetworkServiceClient(func(client networkservice.NetworkServiceClient) networkservice.NetworkServiceClient {
+		rv := client
+		if profileEnabled {
+			rv = profile.NewClient(rv)
+		}
+		rv = trace.NewNetworkServiceClient(rv, trace.WithElement(client))
+		if metricsEnabled {
+			rv = metrics.NewClient(rv, metrics.WithName(typeutils.GetTypeName(client)))
+		}
+		return rv
+	}, clients...)
 }
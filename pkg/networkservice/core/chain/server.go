@@ -21,11 +21,27 @@ package chain
 import (
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
 
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/metrics"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/profile"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/trace"
+	toolsmetrics "github.com/networkservicemesh/sdk/pkg/tools/metrics"
+	"github.com/networkservicemesh/sdk/pkg/tools/typeutils"
 )
 
-// NewNetworkServiceServer - chains together a list of networkservice.Servers with tracing
+// NewNetworkServiceServer - chains together a list of networkservice.NetworkServiceServers with tracing, with metrics if
+// toolsmetrics.IsEnabled() and with self-time profiling if profile.IsEnabled()
 func NewNetworkServiceServer(servers ...networkservice.NetworkServiceServer) networkservice.NetworkServiceServer {
-	return next.NewWrappedNetworkServiceServer(trace.NewNetworkServiceServer, servers...)
+	metricsEnabled, profileEnabled := toolsmetrics.IsEnabled(), profile.IsEnabled()
+	return next.NewWrappedNetworkServiceServer(func(server networkservice.NetworkServiceServer) networkservice.NetworkServiceServer {
+		rv := server
+		if profileEnabled {
+			rv = profile.NewServer(rv)
+		}
+		rv = trace.NewNetworkServiceServer(rv, trace.WithElement(server))
+		if metricsEnabled {
+			rv = metrics.NewServer(rv, metrics.WithName(typeutils.GetTypeName(server)))
+		}
+		return rv
+	}, servers...)
 }
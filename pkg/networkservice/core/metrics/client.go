@@ -0,0 +1,54 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+)
+
+type metricsClient struct {
+	measured networkservice.NetworkServiceClient
+	meter    *meter
+}
+
+// NewClient - wraps metrics collection around the supplied measured
+func NewClient(measured networkservice.NetworkServiceClient, options ...Option) networkservice.NetworkServiceClient {
+	return &metricsClient{
+		measured: measured,
+		meter:    newMeter(measured, clientSide, options),
+	}
+}
+
+func (c *metricsClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	start := time.Now()
+	conn, err := c.measured.Request(ctx, request, opts...)
+	c.meter.request(start, request, conn, err)
+	return conn, err
+}
+
+func (c *metricsClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	start := time.Now()
+	rv, err := c.measured.Close(ctx, conn, opts...)
+	c.meter.close(start, conn, err)
+	return rv, err
+}
@@ -0,0 +1,123 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package metrics provides chain elements collecting Prometheus-style metrics of the wrapped
+// networkservice.NetworkService{Server,Client}: Requests, Closes and errors counters, latency histograms and active
+// connections gauge labeled by the element, network service, mechanism and status code
+package metrics
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	toolsmetrics "github.com/networkservicemesh/sdk/pkg/tools/metrics"
+	"github.com/networkservicemesh/sdk/pkg/tools/typeutils"
+)
+
+const (
+	serverSide = "server"
+	clientSide = "client"
+
+	requestMethod = "Request"
+	closeMethod   = "Close"
+)
+
+type meter struct {
+	element string
+	side    string
+
+	requests *prometheus.CounterVec
+	closes   *prometheus.CounterVec
+	errors   *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+	active   *prometheus.GaugeVec
+
+	// connections maps IDs of the active connections to their network services, so refreshes are counted once
+	connections map[string]string
+	mutex       sync.Mutex
+}
+
+func newMeter(element interface{}, side string, opts []Option) *meter {
+	o := &options{
+		registry: toolsmetrics.DefaultRegistry,
+		name:     typeutils.GetTypeName(element),
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	r := o.registry
+	return &meter{
+		element: o.name,
+		side:    side,
+		requests: r.Counter("nsm_networkservice_requests_total", "Total number of the Requests",
+			"element", "side", "network_service", "mechanism", "code"),
+		closes: r.Counter("nsm_networkservice_closes_total", "Total number of the Closes",
+			"element", "side", "network_service", "mechanism", "code"),
+		errors: r.Counter("nsm_networkservice_errors_total", "Total number of the failed Requests and Closes",
+			"element", "side", "method", "network_service", "code"),
+		duration: r.Histogram("nsm_networkservice_duration_seconds", "Duration of the Requests and Closes in seconds",
+			toolsmetrics.DefaultBuckets, "element", "side", "method", "code"),
+		active: r.Gauge("nsm_networkservice_active_connections", "Number of the active connections",
+			"element", "side", "network_service"),
+		connections: make(map[string]string),
+	}
+}
+
+func (m *meter) request(start time.Time, request *networkservice.NetworkServiceRequest, conn *networkservice.Connection, err error) {
+	if conn == nil {
+		conn = request.GetConnection()
+	}
+	networkService := conn.GetNetworkService()
+	code := status.Code(err).String()
+
+	m.requests.WithLabelValues(m.element, m.side, networkService, conn.GetMechanism().GetType(), code).Inc()
+	m.duration.WithLabelValues(m.element, m.side, requestMethod, code).Observe(time.Since(start).Seconds())
+	if err != nil {
+		m.errors.WithLabelValues(m.element, m.side, requestMethod, networkService, code).Inc()
+		return
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if _, ok := m.connections[conn.GetId()]; !ok {
+		m.connections[conn.GetId()] = networkService
+		m.active.WithLabelValues(m.element, m.side, networkService).Add(1)
+	}
+}
+
+func (m *meter) close(start time.Time, conn *networkservice.Connection, err error) {
+	networkService := conn.GetNetworkService()
+	code := status.Code(err).String()
+
+	m.closes.WithLabelValues(m.element, m.side, networkService, conn.GetMechanism().GetType(), code).Inc()
+	m.duration.WithLabelValues(m.element, m.side, closeMethod, code).Observe(time.Since(start).Seconds())
+	if err != nil {
+		m.errors.WithLabelValues(m.element, m.side, closeMethod, networkService, code).Inc()
+	}
+
+	// The connection is not active after Close even if it has failed
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if activeNetworkService, ok := m.connections[conn.GetId()]; ok {
+		delete(m.connections, conn.GetId())
+		m.active.WithLabelValues(m.element, m.side, activeNetworkService).Add(-1)
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	toolsmetrics "github.com/networkservicemesh/sdk/pkg/tools/metrics"
+)
+
+type options struct {
+	registry *toolsmetrics.Registry
+	name     string
+}
+
+// Option is an option pattern for NewServer, NewClient
+type Option func(o *options)
+
+// WithRegistry sets registry to collect the metrics to, toolsmetrics.DefaultRegistry is used by default
+func WithRegistry(registry *toolsmetrics.Registry) Option {
+	return func(o *options) {
+		o.registry = registry
+	}
+}
+
+// WithName sets the element label of the metrics, the type name of the wrapped element is used by default
+func WithName(name string) Option {
+	return func(o *options) {
+		o.name = name
+	}
+}
@@ -0,0 +1,53 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+)
+
+type metricsServer struct {
+	measured networkservice.NetworkServiceServer
+	meter    *meter
+}
+
+// NewServer - wraps metrics collection around the supplied measured
+func NewServer(measured networkservice.NetworkServiceServer, options ...Option) networkservice.NetworkServiceServer {
+	return &metricsServer{
+		measured: measured,
+		meter:    newMeter(measured, serverSide, options),
+	}
+}
+
+func (s *metricsServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	start := time.Now()
+	conn, err := s.measured.Request(ctx, request)
+	s.meter.request(start, request, conn, err)
+	return conn, err
+}
+
+func (s *metricsServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	start := time.Now()
+	rv, err := s.measured.Close(ctx, conn)
+	s.meter.close(start, conn, err)
+	return rv, err
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/metrics"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/inject/injecterror"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/null"
+	toolsmetrics "github.com/networkservicemesh/sdk/pkg/tools/metrics"
+)
+
+func TestMetricsServer(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	registry := toolsmetrics.NewRegistry()
+	server := metrics.NewServer(null.NewServer(), metrics.WithRegistry(registry), metrics.WithName("test"))
+
+	request := &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id:             "id",
+			NetworkService: "ns",
+			Mechanism:      &networkservice.Mechanism{Type: kernel.MECHANISM},
+		},
+	}
+
+	// Refresh should not be counted as a new active connection
+	for i := 0; i < 2; i++ {
+		_, err := server.Request(context.Background(), request)
+		require.NoError(t, err)
+	}
+
+	requests := registry.Counter("nsm_networkservice_requests_total", "",
+		"element", "side", "network_service", "mechanism", "code")
+	require.Equal(t, 2., testutil.ToFloat64(requests.WithLabelValues("test", "server", "ns", kernel.MECHANISM, "OK")))
+
+	active := registry.Gauge("nsm_networkservice_active_connections", "",
+		"element", "side", "network_service")
+	require.Equal(t, 1., testutil.ToFloat64(active.WithLabelValues("test", "server", "ns")))
+
+	duration := registry.Histogram("nsm_networkservice_duration_seconds", "",
+		toolsmetrics.DefaultBuckets, "element", "side", "method", "code")
+	require.Equal(t, uint64(2), sampleCount(t, duration.WithLabelValues("test", "server", "Request", "OK")))
+
+	_, err := server.Close(context.Background(), request.GetConnection())
+	require.NoError(t, err)
+
+	closes := registry.Counter("nsm_networkservice_closes_total", "",
+		"element", "side", "network_service", "mechanism", "code")
+	require.Equal(t, 1., testutil.ToFloat64(closes.WithLabelValues("test", "server", "ns", kernel.MECHANISM, "OK")))
+	require.Equal(t, 0., testutil.ToFloat64(active.WithLabelValues("test", "server", "ns")))
+}
+
+func TestMetricsServer_Error(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	registry := toolsmetrics.NewRegistry()
+	server := metrics.NewServer(injecterror.NewServer(), metrics.WithRegistry(registry))
+
+	request := &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id:             "id",
+			NetworkService: "ns",
+		},
+	}
+
+	_, err := server.Request(context.Background(), request)
+	require.Error(t, err)
+
+	errs := registry.Counter("nsm_networkservice_errors_total", "",
+		"element", "side", "method", "network_service", "code")
+	require.Equal(t, 1., testutil.ToFloat64(errs.WithLabelValues("injecterror.injectErrorServer", "server", "Request", "ns", "Unknown")))
+
+	active := registry.Gauge("nsm_networkservice_active_connections", "",
+		"element", "side", "network_service")
+	require.Equal(t, 0., testutil.ToFloat64(active.WithLabelValues("injecterror.injectErrorServer", "server", "ns")))
+}
+
+func sampleCount(t *testing.T, observer prometheus.Observer) uint64 {
+	m := new(dto.Metric)
+	require.NoError(t, observer.(prometheus.Metric).Write(m))
+	return m.GetHistogram().GetSampleCount()
+}
@@ -0,0 +1,68 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package profile
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/typeutils"
+)
+
+type profileClient struct {
+	profiled networkservice.NetworkServiceClient
+	profiler *Profiler
+	request  string
+	close    string
+}
+
+// NewClient - wraps self-time profiling around the supplied profiled
+func NewClient(profiled networkservice.NetworkServiceClient, opts ...Option) networkservice.NetworkServiceClient {
+	o := &options{
+		profiler: DefaultProfiler,
+		element:  profiled,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return &profileClient{
+		profiled: profiled,
+		profiler: o.profiler,
+		request:  typeutils.GetFuncName(o.element, requestMethod),
+		close:    typeutils.GetFuncName(o.element, closeMethod),
+	}
+}
+
+func (c *profileClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (conn *networkservice.Connection, err error) {
+	self := measure(ctx, func(ctx context.Context) {
+		conn, err = c.profiled.Request(ctx, request, opts...)
+	})
+	c.profiler.observe(c.request, clientSide, requestMethod, self)
+	return conn, err
+}
+
+func (c *profileClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (rv *empty.Empty, err error) {
+	self := measure(ctx, func(ctx context.Context) {
+		rv, err = c.profiled.Close(ctx, conn, opts...)
+	})
+	c.profiler.observe(c.close, clientSide, closeMethod, self)
+	return rv, err
+}
@@ -0,0 +1,68 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package profile
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+type contextKeyType string
+
+const frameKey contextKeyType = "ProfileFrame"
+
+// frame accumulates the time spent in the downstream profiled elements
+type frame struct {
+	downstream int64
+}
+
+func (f *frame) add(d time.Duration) {
+	atomic.AddInt64(&f.downstream, int64(d))
+}
+
+func (f *frame) downstreamTime() time.Duration {
+	return time.Duration(atomic.LoadInt64(&f.downstream))
+}
+
+func withFrame(parent context.Context, f *frame) context.Context {
+	return context.WithValue(parent, frameKey, f)
+}
+
+func frameFromContext(ctx context.Context) *frame {
+	if rv, ok := ctx.Value(frameKey).(*frame); ok {
+		return rv
+	}
+	return nil
+}
+
+// measure calls call with the new frame in context and returns its self-time excluding the downstream profiled
+// elements, the total time is added to the upstream frame
+func measure(ctx context.Context, call func(ctx context.Context)) time.Duration {
+	f := new(frame)
+	start := time.Now()
+	call(withFrame(ctx, f))
+	total := time.Since(start)
+
+	if upstream := frameFromContext(ctx); upstream != nil {
+		upstream.add(total)
+	}
+	if self := total - f.downstreamTime(); self > 0 {
+		return self
+	}
+	return 0
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package profile
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+type elementReport struct {
+	Element      string  `json:"element"`
+	Side         string  `json:"side"`
+	Count        uint64  `json:"count"`
+	TotalSeconds float64 `json:"total_seconds"`
+	MeanSeconds  float64 `json:"mean_seconds"`
+	MaxSeconds   float64 `json:"max_seconds"`
+	Share        float64 `json:"share"`
+}
+
+type report struct {
+	Method       string           `json:"method"`
+	TotalSeconds float64          `json:"total_seconds"`
+	Dominant     string           `json:"dominant,omitempty"`
+	Elements     []*elementReport `json:"elements"`
+}
+
+// Handler returns http.Handler reporting the elements self-time of the method (?method=Close, Request by default) as
+// JSON sorted by total self-time, the first one is the dominant element. DELETE resets the aggregated Stats.
+func Handler(p *Profiler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			p.Reset()
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		method := r.URL.Query().Get("method")
+		if method == "" {
+			method = requestMethod
+		}
+		stats := p.Stats(method)
+
+		rv := &report{
+			Method:   method,
+			Elements: make([]*elementReport, 0, len(stats)),
+		}
+		for i := range stats {
+			rv.TotalSeconds += stats[i].Total.Seconds()
+			rv.Elements = append(rv.Elements, &elementReport{
+				Element:      stats[i].Element,
+				Side:         stats[i].Side,
+				Count:        stats[i].Count,
+				TotalSeconds: stats[i].Total.Seconds(),
+				MeanSeconds:  stats[i].Mean().Seconds(),
+				MaxSeconds:   stats[i].Max.Seconds(),
+			})
+		}
+		for _, e := range rv.Elements {
+			if rv.TotalSeconds > 0 {
+				e.Share = e.TotalSeconds / rv.TotalSeconds
+			}
+		}
+		if len(rv.Elements) > 0 {
+			rv.Dominant = rv.Elements[0].Element
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(rv)
+	})
+}
@@ -0,0 +1,40 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package profile
+
+type options struct {
+	profiler *Profiler
+	element  interface{}
+}
+
+// Option is an option pattern for NewServer, NewClient
+type Option func(o *options)
+
+// WithProfiler sets profiler to collect the self-time to, DefaultProfiler is used by default
+func WithProfiler(profiler *Profiler) Option {
+	return func(o *options) {
+		o.profiler = profiler
+	}
+}
+
+// WithElement sets the element which type names the profile (see typeutils.GetFuncName), the wrapped element is used
+// by default
+func WithElement(element interface{}) Option {
+	return func(o *options) {
+		o.element = element
+	}
+}
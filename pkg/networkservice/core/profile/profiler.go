@@ -0,0 +1,127 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package profile provides chain elements measuring self-time of the wrapped networkservice.NetworkService{Server,Client}
+// excluding the time spent in the downstream profiled elements, so the element dominating Request latency can be found
+package profile
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	toolsmetrics "github.com/networkservicemesh/sdk/pkg/tools/metrics"
+)
+
+const (
+	serverSide = "server"
+	clientSide = "client"
+
+	requestMethod = "Request"
+	closeMethod   = "Close"
+)
+
+// DefaultProfiler is the profiler used by the chain elements by default
+var DefaultProfiler = NewProfiler(toolsmetrics.DefaultRegistry)
+
+// Stats is an aggregated self-time of the element method
+type Stats struct {
+	Element string        `json:"element"`
+	Side    string        `json:"side"`
+	Method  string        `json:"method"`
+	Count   uint64        `json:"count"`
+	Total   time.Duration `json:"total"`
+	Max     time.Duration `json:"max"`
+}
+
+// Mean returns the mean self-time
+func (s *Stats) Mean() time.Duration {
+	if s.Count == 0 {
+		return 0
+	}
+	return s.Total / time.Duration(s.Count)
+}
+
+type statsKey struct {
+	element, side, method string
+}
+
+// Profiler aggregates self-time of the elements into histograms and Stats
+type Profiler struct {
+	selfTime *prometheus.HistogramVec
+
+	mutex sync.Mutex
+	stats map[statsKey]*Stats
+}
+
+// NewProfiler creates a Profiler registering nsm_networkservice_self_duration_seconds histogram in registry
+func NewProfiler(registry *toolsmetrics.Registry) *Profiler {
+	return &Profiler{
+		selfTime: registry.Histogram("nsm_networkservice_self_duration_seconds",
+			"Self-time of the chain elements excluding the downstream elements in seconds",
+			toolsmetrics.DefaultBuckets, "element", "side", "method"),
+		stats: make(map[statsKey]*Stats),
+	}
+}
+
+func (p *Profiler) observe(element, side, method string, self time.Duration) {
+	p.selfTime.WithLabelValues(element, side, method).Observe(self.Seconds())
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	key := statsKey{element: element, side: side, method: method}
+	s, ok := p.stats[key]
+	if !ok {
+		s = &Stats{Element: element, Side: side, Method: method}
+		p.stats[key] = s
+	}
+	s.Count++
+	s.Total += self
+	if self > s.Max {
+		s.Max = self
+	}
+}
+
+// Stats returns copies of the method Stats sorted by total self-time descending, all the methods if method is empty
+func (p *Profiler) Stats(method string) []Stats {
+	p.mutex.Lock()
+	rv := make([]Stats, 0, len(p.stats))
+	for _, s := range p.stats {
+		if method == "" || s.Method == method {
+			rv = append(rv, *s)
+		}
+	}
+	p.mutex.Unlock()
+
+	sort.Slice(rv, func(i, j int) bool {
+		if rv[i].Total != rv[j].Total {
+			return rv[i].Total > rv[j].Total
+		}
+		return rv[i].Element < rv[j].Element
+	})
+	return rv
+}
+
+// Reset drops the aggregated Stats, the histograms are kept
+func (p *Profiler) Reset() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.stats = make(map[statsKey]*Stats)
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package profile
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/typeutils"
+)
+
+type profileServer struct {
+	profiled networkservice.NetworkServiceServer
+	profiler *Profiler
+	request  string
+	close    string
+}
+
+// NewServer - wraps self-time profiling around the supplied profiled
+func NewServer(profiled networkservice.NetworkServiceServer, opts ...Option) networkservice.NetworkServiceServer {
+	o := &options{
+		profiler: DefaultProfiler,
+		element:  profiled,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return &profileServer{
+		profiled: profiled,
+		profiler: o.profiler,
+		request:  typeutils.GetFuncName(o.element, requestMethod),
+		close:    typeutils.GetFuncName(o.element, closeMethod),
+	}
+}
+
+func (s *profileServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (conn *networkservice.Connection, err error) {
+	self := measure(ctx, func(ctx context.Context) {
+		conn, err = s.profiled.Request(ctx, request)
+	})
+	s.profiler.observe(s.request, serverSide, requestMethod, self)
+	return conn, err
+}
+
+func (s *profileServer) Close(ctx context.Context, conn *networkservice.Connection) (rv *empty.Empty, err error) {
+	self := measure(ctx, func(ctx context.Context) {
+		rv, err = s.profiled.Close(ctx, conn)
+	})
+	s.profiler.observe(s.close, serverSide, closeMethod, self)
+	return rv, err
+}
@@ -0,0 +1,137 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package profile_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/profile"
+	toolsmetrics "github.com/networkservicemesh/sdk/pkg/tools/metrics"
+	"github.com/networkservicemesh/sdk/pkg/tools/typeutils"
+)
+
+const (
+	fastDelay = 10 * time.Millisecond
+	slowDelay = 100 * time.Millisecond
+)
+
+type fastServer struct{}
+
+func (s *fastServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	time.Sleep(fastDelay)
+	return next.Server(ctx).Request(ctx, request)
+}
+
+func (s *fastServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	return next.Server(ctx).Close(ctx, conn)
+}
+
+type slowServer struct{}
+
+func (s *slowServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	time.Sleep(slowDelay)
+	return next.Server(ctx).Request(ctx, request)
+}
+
+func (s *slowServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	return next.Server(ctx).Close(ctx, conn)
+}
+
+func TestProfileServer(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	registry := toolsmetrics.NewRegistry()
+	profiler := profile.NewProfiler(registry)
+	server := next.NewWrappedNetworkServiceServer(func(server networkservice.NetworkServiceServer) networkservice.NetworkServiceServer {
+		return profile.NewServer(server, profile.WithProfiler(profiler))
+	}, &fastServer{}, &slowServer{})
+
+	_, err := server.Request(context.Background(), &networkservice.NetworkServiceRequest{})
+	require.NoError(t, err)
+
+	fast := typeutils.GetFuncName(&fastServer{}, "Request")
+	slow := typeutils.GetFuncName(&slowServer{}, "Request")
+
+	stats := profiler.Stats("Request")
+	require.Len(t, stats, 2)
+
+	// fastServer self-time should not include slowServer time
+	require.Equal(t, slow, stats[0].Element)
+	require.GreaterOrEqual(t, int64(stats[0].Total), int64(slowDelay))
+	require.Equal(t, fast, stats[1].Element)
+	require.GreaterOrEqual(t, int64(stats[1].Total), int64(fastDelay))
+	require.Less(t, int64(stats[1].Total), int64(slowDelay))
+
+	selfTime := registry.Histogram("nsm_networkservice_self_duration_seconds", "",
+		toolsmetrics.DefaultBuckets, "element", "side", "method")
+	require.Equal(t, uint64(1), sampleCount(t, selfTime.WithLabelValues(slow, "server", "Request")))
+	require.Equal(t, uint64(1), sampleCount(t, selfTime.WithLabelValues(fast, "server", "Request")))
+}
+
+func sampleCount(t *testing.T, observer prometheus.Observer) uint64 {
+	m := new(dto.Metric)
+	require.NoError(t, observer.(prometheus.Metric).Write(m))
+	return m.GetHistogram().GetSampleCount()
+}
+
+func TestHandler(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	profiler := profile.NewProfiler(toolsmetrics.NewRegistry())
+	server := next.NewWrappedNetworkServiceServer(func(server networkservice.NetworkServiceServer) networkservice.NetworkServiceServer {
+		return profile.NewServer(server, profile.WithProfiler(profiler))
+	}, &slowServer{}, &fastServer{})
+
+	_, err := server.Request(context.Background(), &networkservice.NetworkServiceRequest{})
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	profile.Handler(profiler).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var report struct {
+		Method   string `json:"method"`
+		Dominant string `json:"dominant"`
+		Elements []struct {
+			Element string  `json:"element"`
+			Share   float64 `json:"share"`
+		} `json:"elements"`
+	}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&report))
+	require.Equal(t, "Request", report.Method)
+	require.Equal(t, typeutils.GetFuncName(&slowServer{}, "Request"), report.Dominant)
+	require.Len(t, report.Elements, 2)
+	require.Greater(t, report.Elements[0].Share, 0.5)
+
+	w = httptest.NewRecorder()
+	profile.Handler(profiler).ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/", nil))
+	require.Equal(t, http.StatusNoContent, w.Code)
+	require.Empty(t, profiler.Stats(""))
+}
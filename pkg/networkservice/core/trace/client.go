@@ -25,67 +25,78 @@ import (
 
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
 
+	"github.com/networkservicemesh/sdk/pkg/tools/nsmerrors"
 	"github.com/networkservicemesh/sdk/pkg/tools/spanhelper"
 	"github.com/networkservicemesh/sdk/pkg/tools/typeutils"
 )
 
 type traceClient struct {
-	traced networkservice.NetworkServiceClient
+	traced  networkservice.NetworkServiceClient
+	element interface{}
 }
 
 // NewNetworkServiceClient - wraps tracing around the supplied networkservice.NetworkServiceClient
-func NewNetworkServiceClient(traced networkservice.NetworkServiceClient) networkservice.NetworkServiceClient {
-	return &traceClient{traced: traced}
+func NewNetworkServiceClient(traced networkservice.NetworkServiceClient, opts ...Option) networkservice.NetworkServiceClient {
+	o := &options{
+		element: traced,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return &traceClient{
+		traced:  traced,
+		element: o.element,
+	}
 }
 
 func (t *traceClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
 	// Create a new span
-	operation := typeutils.GetFuncName(t.traced, "Request")
+	operation := typeutils.GetFuncName(t.element, "Request")
 	span := spanhelper.FromContext(ctx, operation)
 	defer span.Finish()
 
 	// Make sure we log to span
 
-	ctx = withLog(span.Context(), span.Logger())
+	ctx = withTraceInfo(withComponentLog(span.Context(), span, t.element, request.GetConnection()))
 
-	span.LogObject("request", request)
+	logRequest(ctx, span, request)
 
 	// Actually call the next
 	rv, err := t.traced.Request(ctx, request, opts...)
 
 	if err != nil {
 		if _, ok := err.(stackTracer); !ok {
-			err = errors.Wrapf(err, "Error returned from %s", operation)
+			err = nsmerrors.KeepStatus(errors.Wrapf(err, "Error returned from %s", operation))
 			span.LogErrorf("%+v", err)
 			return nil, err
 		}
 		span.LogErrorf("%v", err)
-		return nil, err
+		return nil, nsmerrors.KeepStatus(err)
 	}
-	span.LogObject("response", rv)
+	logResponse(ctx, span, rv)
 	return rv, err
 }
 
 func (t *traceClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
 	// Create a new span
-	operation := typeutils.GetFuncName(t.traced, "Close")
+	operation := typeutils.GetFuncName(t.element, "Close")
 	span := spanhelper.FromContext(ctx, operation)
 	defer span.Finish()
 	// Make sure we log to span
-	ctx = withLog(span.Context(), span.Logger())
+	ctx = withTraceInfo(withComponentLog(span.Context(), span, t.element, conn))
 
-	span.LogObject("request", conn)
+	logRequest(ctx, span, conn)
 	rv, err := t.traced.Close(ctx, conn, opts...)
 
 	if err != nil {
 		if _, ok := err.(stackTracer); !ok {
-			err = errors.Wrapf(err, "Error returned from %s", operation)
+			err = nsmerrors.KeepStatus(errors.Wrapf(err, "Error returned from %s", operation))
 			span.LogErrorf("%+v", err)
 			return nil, err
 		}
 		span.LogErrorf("%v", err)
-		return nil, err
+		return nil, nsmerrors.KeepStatus(err)
 	}
-	span.LogObject("response", rv)
+	logResponse(ctx, span, rv)
 	return rv, err
 }
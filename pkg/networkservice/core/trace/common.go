@@ -0,0 +1,132 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/jsonpatch"
+	"github.com/networkservicemesh/sdk/pkg/tools/redact"
+	"github.com/networkservicemesh/sdk/pkg/tools/spanhelper"
+)
+
+const truncatedSuffix = "..."
+
+// traceInfo is the last request and response logged in the chain
+type traceInfo struct {
+	mutex       sync.Mutex
+	request     proto.Message
+	requestSpan spanhelper.SpanHelper
+	response    proto.Message
+}
+
+func withTraceInfo(parent context.Context) context.Context {
+	if _, ok := parent.Value(traceInfoKey).(*traceInfo); ok {
+		return parent
+	}
+	return context.WithValue(parent, traceInfoKey, new(traceInfo))
+}
+
+func traceInfoFromContext(ctx context.Context) *traceInfo {
+	if rv, ok := ctx.Value(traceInfoKey).(*traceInfo); ok {
+		return rv
+	}
+	return new(traceInfo)
+}
+
+// logRequest logs request if tracing is enabled. If IsDiffEnabled(), only the first traced element logs the whole
+// request, and the diff of the request received by the previous traced element and the request it has passed to the
+// next one is logged in the span of the previous element.
+func logRequest(ctx context.Context, span spanhelper.SpanHelper, request proto.Message) {
+	if !spanhelper.IsTracingEnabled() {
+		return
+	}
+	msg := redact.Message(request)
+	if !IsDiffEnabled() {
+		logObject(span, "request", msg)
+		return
+	}
+
+	info := traceInfoFromContext(ctx)
+	info.mutex.Lock()
+	prev, prevSpan := info.request, info.requestSpan
+	info.request, info.requestSpan = msg, span
+	info.mutex.Unlock()
+
+	if prev == nil || reflect.TypeOf(prev) != reflect.TypeOf(msg) {
+		logObject(span, "request", msg)
+		return
+	}
+	logDiff(prevSpan, "request", prev, msg)
+}
+
+// logResponse logs response if tracing is enabled. If IsDiffEnabled(), only the last traced element logs the whole
+// response, the others log the diff of the response received from the next traced element and the returned one.
+func logResponse(ctx context.Context, span spanhelper.SpanHelper, response proto.Message) {
+	if !spanhelper.IsTracingEnabled() {
+		return
+	}
+	msg := redact.Message(response)
+	if !IsDiffEnabled() {
+		logObject(span, "response", msg)
+		return
+	}
+
+	info := traceInfoFromContext(ctx)
+	info.mutex.Lock()
+	prev := info.response
+	info.response = msg
+	info.mutex.Unlock()
+
+	if prev == nil || reflect.TypeOf(prev) != reflect.TypeOf(msg) {
+		logObject(span, "response", msg)
+		return
+	}
+	logDiff(span, "response", prev, msg)
+}
+
+// logDiff logs JSON Patch from prev to msg if they differ, or msg if the patch can't be created
+func logDiff(span spanhelper.SpanHelper, attribute string, prev, msg proto.Message) {
+	patch, err := jsonpatch.Diff(prev, msg)
+	if err != nil {
+		logObject(span, attribute, msg)
+		return
+	}
+	if len(patch) > 0 {
+		logObject(span, attribute+"-diff", patch)
+	}
+}
+
+// logObject logs JSON of value truncated to MaxObjectSize()
+func logObject(span spanhelper.SpanHelper, attribute string, value interface{}) {
+	msg := ""
+	if bytes, err := json.Marshal(value); err == nil {
+		msg = string(bytes)
+	} else {
+		msg = fmt.Sprint(value)
+	}
+	if maxSize := MaxObjectSize(); len(msg) > maxSize {
+		msg = msg[:maxSize] + truncatedSuffix
+	}
+	span.LogValue(attribute, msg)
+}
@@ -19,14 +19,22 @@ package trace
 
 import (
 	"context"
+	"strings"
 
 	"github.com/sirupsen/logrus"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/sdk/pkg/tools/spanhelper"
+	"github.com/networkservicemesh/sdk/pkg/tools/typeutils"
 )
 
 type contextKeyType string
 
 const (
-	logKey contextKeyType = "Log"
+	logKey       contextKeyType = "Log"
+	traceInfoKey contextKeyType = "TraceInfo"
 )
 
 // withLog -
@@ -38,6 +46,25 @@ func withLog(parent context.Context, log logrus.FieldLogger) context.Context {
 	return context.WithValue(parent, logKey, log)
 }
 
+// withComponentLog -
+//   Provides the span FieldLogger with the traced component level and the connection fields in context, sets the
+//   component and the connection for log.FromContext
+func withComponentLog(parent context.Context, span spanhelper.SpanHelper, traced interface{}, conn *networkservice.Connection) context.Context {
+	component := componentName(traced)
+	ctx := log.WithConnection(log.WithComponent(parent, component), conn.GetId(), conn.GetNetworkService())
+	logger := log.ApplyLevel(span.Logger(), component).WithFields(logrus.Fields{
+		log.ComponentField:      component,
+		log.ConnectionIDField:   conn.GetId(),
+		log.NetworkServiceField: conn.GetNetworkService(),
+	})
+	return withLog(ctx, logger)
+}
+
+// componentName returns the package name of the traced element, e.g. "heal" for *heal.healClient
+func componentName(traced interface{}) string {
+	return strings.SplitN(typeutils.GetTypeName(traced), ".", 2)[0]
+}
+
 // Log - return FieldLogger from context
 func Log(ctx context.Context) logrus.FieldLogger {
 	rv, ok := ctx.Value(logKey).(logrus.FieldLogger)
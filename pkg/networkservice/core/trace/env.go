@@ -0,0 +1,49 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace
+
+import (
+	"os"
+	"strconv"
+)
+
+const (
+	diffEnv              = "TRACER_LOG_DIFF"
+	diffDefault          = false
+	maxObjectSizeEnv     = "TRACER_LOG_MAX_SIZE"
+	maxObjectSizeDefault = 4096
+)
+
+// IsDiffEnabled returns true if TRACER_LOG_DIFF environment variable is true, then each traced element logs only JSON
+// Patch between the request (response) it has received and the request (response) it has passed on
+func IsDiffEnabled() bool {
+	val, err := strconv.ParseBool(os.Getenv(diffEnv))
+	if err != nil {
+		return diffDefault
+	}
+	return val
+}
+
+// MaxObjectSize returns max size in bytes of the logged objects set by TRACER_LOG_MAX_SIZE environment variable,
+// larger objects are truncated
+func MaxObjectSize() int {
+	val, err := strconv.Atoi(os.Getenv(maxObjectSizeEnv))
+	if err != nil || val <= 0 {
+		return maxObjectSizeDefault
+	}
+	return val
+}
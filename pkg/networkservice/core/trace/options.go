@@ -0,0 +1,32 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace
+
+type options struct {
+	element interface{}
+}
+
+// Option is an option pattern for NewNetworkServiceServer, NewNetworkServiceClient
+type Option func(o *options)
+
+// WithElement sets the element which type names the spans and the component logs (see typeutils.GetFuncName), the
+// traced element is used by default
+func WithElement(element interface{}) Option {
+	return func(o *options) {
+		o.element = element
+	}
+}
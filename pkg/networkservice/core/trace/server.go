@@ -24,67 +24,78 @@ import (
 
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
 
+	"github.com/networkservicemesh/sdk/pkg/tools/nsmerrors"
 	"github.com/networkservicemesh/sdk/pkg/tools/spanhelper"
 	"github.com/networkservicemesh/sdk/pkg/tools/typeutils"
 )
 
 type traceServer struct {
-	traced networkservice.NetworkServiceServer
+	traced  networkservice.NetworkServiceServer
+	element interface{}
 }
 
 // NewNetworkServiceServer - wraps tracing around the supplied traced
-func NewNetworkServiceServer(traced networkservice.NetworkServiceServer) networkservice.NetworkServiceServer {
-	return &traceServer{traced: traced}
+func NewNetworkServiceServer(traced networkservice.NetworkServiceServer, opts ...Option) networkservice.NetworkServiceServer {
+	o := &options{
+		element: traced,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return &traceServer{
+		traced:  traced,
+		element: o.element,
+	}
 }
 
 func (t *traceServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
 	// Create a new span
-	operation := typeutils.GetFuncName(t.traced, "Request")
+	operation := typeutils.GetFuncName(t.element, "Request")
 	span := spanhelper.FromContext(ctx, operation)
 	defer span.Finish()
 
 	// Make sure we log to span
 
-	ctx = withLog(span.Context(), span.Logger())
+	ctx = withTraceInfo(withComponentLog(span.Context(), span, t.element, request.GetConnection()))
 
-	span.LogObject("request", request)
+	logRequest(ctx, span, request)
 
 	// Actually call the next
 	rv, err := t.traced.Request(ctx, request)
 
 	if err != nil {
 		if _, ok := err.(stackTracer); !ok {
-			err = errors.Wrapf(err, "Error returned from %s", operation)
+			err = nsmerrors.KeepStatus(errors.Wrapf(err, "Error returned from %s", operation))
 			span.LogErrorf("%+v", err)
 			return nil, err
 		}
 		span.LogErrorf("%v", err)
-		return nil, err
+		return nil, nsmerrors.KeepStatus(err)
 	}
-	span.LogObject("response", rv)
+	logResponse(ctx, span, rv)
 	return rv, err
 }
 
 func (t *traceServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
 	// Create a new span
-	operation := typeutils.GetFuncName(t.traced, "Close")
+	operation := typeutils.GetFuncName(t.element, "Close")
 	span := spanhelper.FromContext(ctx, operation)
 	defer span.Finish()
 	// Make sure we log to span
-	ctx = withLog(span.Context(), span.Logger())
+	ctx = withTraceInfo(withComponentLog(span.Context(), span, t.element, conn))
 
-	span.LogObject("request", conn)
+	logRequest(ctx, span, conn)
 	rv, err := t.traced.Close(ctx, conn)
 
 	if err != nil {
 		if _, ok := err.(stackTracer); !ok {
-			err = errors.Wrapf(err, "Error returned from %s", operation)
+			err = nsmerrors.KeepStatus(errors.Wrapf(err, "Error returned from %s", operation))
 			span.LogErrorf("%+v", err)
 			return nil, err
 		}
 		span.LogErrorf("%v", err)
-		return nil, err
+		return nil, nsmerrors.KeepStatus(err)
 	}
-	span.LogObject("response", rv)
+	logResponse(ctx, span, rv)
 	return rv, err
 }
@@ -0,0 +1,156 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/mocktracer"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/chain"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/inject/injecterror"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/null"
+	"github.com/networkservicemesh/sdk/pkg/tools/nsmerrors"
+)
+
+type labelServer struct{}
+
+func (s *labelServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	request.GetConnection().Labels = map[string]string{"app": "nsc"}
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	conn.Context = &networkservice.ConnectionContext{ExtraContext: map[string]string{"mtu": "1500"}}
+	return conn, nil
+}
+
+func (s *labelServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	return next.Server(ctx).Close(ctx, conn)
+}
+
+// spanLogs returns the values logged with key in the finished spans with operation containing name
+func spanLogs(tracer *mocktracer.MockTracer, name, key string) (rv []string) {
+	for _, span := range tracer.FinishedSpans() {
+		if !strings.Contains(span.OperationName, name) {
+			continue
+		}
+		for _, record := range span.Logs() {
+			for _, field := range record.Fields {
+				if field.Key == key {
+					rv = append(rv, field.ValueString)
+				}
+			}
+		}
+	}
+	return rv
+}
+
+func TestTraceServer_Diff(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	require.NoError(t, os.Setenv("TRACER_ENABLED", "true"))
+	require.NoError(t, os.Setenv("TRACER_LOG_DIFF", "true"))
+	defer func() {
+		_ = os.Unsetenv("TRACER_ENABLED")
+		_ = os.Unsetenv("TRACER_LOG_DIFF")
+	}()
+
+	tracer := mocktracer.New()
+	opentracing.SetGlobalTracer(tracer)
+	defer opentracing.SetGlobalTracer(opentracing.NoopTracer{})
+
+	server := chain.NewNetworkServiceServer(&labelServer{}, null.NewServer())
+
+	request := &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id: "id",
+			Path: &networkservice.Path{
+				PathSegments: []*networkservice.PathSegment{{Name: "nsc", Token: "secret-token"}},
+			},
+		},
+	}
+	_, err := server.Request(context.Background(), request)
+	require.NoError(t, err)
+
+	// The first element logs the whole request and the labels it has passed to the next element
+	requests := spanLogs(tracer, "labelServer", "request")
+	require.Len(t, requests, 1)
+	require.NotContains(t, requests[0], "secret-token")
+	require.Contains(t, requests[0], `"token":"REDACTED"`)
+	require.Equal(t, []string{`[{"op":"add","path":"/connection/labels","value":{"app":"nsc"}}]`},
+		spanLogs(tracer, "labelServer", "request-diff"))
+	require.Empty(t, spanLogs(tracer, "nullServer", "request-diff"))
+
+	// The last element logs the whole response, the first one logs the context it has added
+	require.Len(t, spanLogs(tracer, "nullServer", "response"), 1)
+	require.Equal(t, []string{`[{"op":"add","path":"/context","value":{"extra_context":{"mtu":"1500"}}}]`},
+		spanLogs(tracer, "labelServer", "response-diff"))
+}
+
+func TestTraceServer_TracingDisabled(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	require.NoError(t, os.Setenv("TRACER_ENABLED", "false"))
+	defer func() { _ = os.Unsetenv("TRACER_ENABLED") }()
+
+	out := new(bytes.Buffer)
+	logrus.SetOutput(out)
+	defer logrus.SetOutput(os.Stderr)
+
+	server := chain.NewNetworkServiceServer(&labelServer{}, null.NewServer())
+	_, err := server.Request(context.Background(), &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{Id: "id"},
+	})
+	require.NoError(t, err)
+	require.NotContains(t, out.String(), "request=")
+	require.NotContains(t, out.String(), "response=")
+}
+
+func TestTraceServer_KeepsStatus(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	server := chain.NewNetworkServiceServer(
+		null.NewServer(),
+		injecterror.NewServer(nsmerrors.New(nsmerrors.ReasonUnsupportedMechanism, "Unsupported Mechanism")),
+	)
+	_, err := server.Request(context.Background(), &networkservice.NetworkServiceRequest{})
+	require.Equal(t, codes.Unimplemented, status.Code(err))
+	reason, ok := nsmerrors.ReasonOf(err)
+	require.True(t, ok)
+	require.Equal(t, nsmerrors.ReasonUnsupportedMechanism, reason)
+
+	server = chain.NewNetworkServiceServer(
+		null.NewServer(),
+		injecterror.NewServer(status.Error(codes.PermissionDenied, "no sufficient privileges")),
+	)
+	_, err = server.Request(context.Background(), &networkservice.NetworkServiceRequest{})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+}
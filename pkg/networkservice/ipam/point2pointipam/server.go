@@ -26,14 +26,13 @@ import (
 	"net"
 	"sync"
 
-	"github.com/pkg/errors"
-
 	"github.com/RoaringBitmap/roaring"
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
 
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
 	"github.com/networkservicemesh/sdk/pkg/tools/cidr"
+	"github.com/networkservicemesh/sdk/pkg/tools/nsmerrors"
 )
 
 type pointToPointServer struct {
@@ -53,7 +52,7 @@ func (srv *pointToPointServer) Request(ctx context.Context, request *networkserv
 	defer srv.mutex.Unlock()
 
 	if srv.freeIPs.IsEmpty() {
-		return nil, errors.New("ipam allocation pool depleted")
+		return nil, nsmerrors.New(nsmerrors.ReasonIPAMExhausted, "ipam allocation pool depleted")
 	}
 
 	if request.GetConnection() == nil {
@@ -76,7 +75,7 @@ func (srv *pointToPointServer) Request(ctx context.Context, request *networkserv
 	for _, prefix := range excludePrefixes {
 		_, ipnet, err := net.ParseCIDR(prefix)
 		if err != nil {
-			return nil, err
+			return nil, nsmerrors.Wrap(err, nsmerrors.ReasonIPAMInvalidPrefix, "invalid excluded prefix %s", prefix)
 		}
 		low := binary.BigEndian.Uint32(cidr.NetworkAddress(ipnet).To4())
 		high := binary.BigEndian.Uint32(cidr.BroadcastAddress(ipnet).To4()) + 1
@@ -86,14 +85,14 @@ func (srv *pointToPointServer) Request(ctx context.Context, request *networkserv
 
 	available := roaring.And(roaring.Xor(srv.freeIPs, exclude), srv.freeIPs)
 	if available.IsEmpty() {
-		return nil, errors.New("available IP addresses excluded by request")
+		return nil, nsmerrors.New(nsmerrors.ReasonIPAMExcluded, "available IP addresses excluded by request")
 	}
 	dstInt := available.Minimum()
 	available.Remove(dstInt)
 
 	// explicitly check again, panics if empty on minimum and remove calls
 	if available.IsEmpty() {
-		return nil, errors.New("available IP addresses excluded by request")
+		return nil, nsmerrors.New(nsmerrors.ReasonIPAMExcluded, "available IP addresses excluded by request")
 	}
 	srcInt := available.Minimum()
 	available.Remove(srcInt)
@@ -142,13 +141,13 @@ func (srv *pointToPointServer) Close(ctx context.Context, conn *networkservice.C
 
 func (srv *pointToPointServer) init() {
 	if len(srv.prefixes) == 0 {
-		srv.initErr = errors.New("required one or more prefixes")
+		srv.initErr = nsmerrors.New(nsmerrors.ReasonIPAMInvalidConfig, "required one or more prefixes")
 		return
 	}
 
 	for _, prefix := range srv.prefixes {
 		if prefix == nil {
-			srv.initErr = errors.Errorf("prefix must not be nil: %+v", srv.prefixes)
+			srv.initErr = nsmerrors.New(nsmerrors.ReasonIPAMInvalidConfig, "prefix must not be nil: %+v", srv.prefixes)
 			return
 		}
 	}
@@ -22,6 +22,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/networkservicemesh/sdk/pkg/networkservice/ipam/point2pointipam"
 
@@ -79,6 +81,7 @@ func TestNilPrefixes(t *testing.T) {
 	srv := point2pointipam.NewServer()
 	_, err := srv.Request(context.Background(), newRequest())
 	require.Error(t, err)
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
 	_, cidr1, _ := net.ParseCIDR("192.168.0.1/32")
 
 	srv = point2pointipam.NewServer(
@@ -89,6 +92,7 @@ func TestNilPrefixes(t *testing.T) {
 	_, err = srv.Request(context.Background(), newRequest())
 
 	assert.Error(t, err)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
 }
 
 func TestExclude32Prefix(t *testing.T) {
@@ -155,3 +159,18 @@ func TestAllIPsExcluded(t *testing.T) {
 	assert.Nil(t, conn1)
 	assert.Error(t, err)
 }
+
+func TestInvalidExcludedPrefix(t *testing.T) {
+	_, ipnet, err := net.ParseCIDR("192.168.1.2/31")
+	require.NoError(t, err)
+	srv := point2pointipam.NewServer(ipnet)
+
+	req := newRequest()
+	req.Connection.Context.IpContext.ExcludedPrefixes = []string{
+		"192.168.1.2",
+	}
+	conn, err := srv.Request(context.Background(), req)
+	require.Nil(t, conn)
+	require.Error(t, err)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
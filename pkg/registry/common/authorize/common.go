@@ -0,0 +1,141 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authorize
+
+import (
+	"context"
+	"sync"
+
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/opa"
+	"github.com/networkservicemesh/sdk/pkg/tools/ownership"
+	"github.com/networkservicemesh/sdk/pkg/tools/peerid"
+)
+
+// Input is OPA input for registry authorization policies
+type Input struct {
+	// SpiffeID is SPIFFE ID of the caller, empty if the caller has no SVID
+	SpiffeID string `json:"spiffe_id"`
+	// Name is the name of the registered resource
+	Name string `json:"name"`
+	// Owner is SPIFFE ID of the caller that has registered the resource, empty if the resource is not owned
+	Owner string `json:"owner"`
+}
+
+type authorizePolicies struct {
+	policies []opa.AuthorizationPolicy
+}
+
+func (a *authorizePolicies) check(ctx context.Context, input *Input) error {
+	for _, p := range a.policies {
+		if err := p.Check(ctx, input); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// owners checks the registry requests by the policies and keeps the owners of the registered names
+type owners struct {
+	policies *authorizePolicies
+	table    *ownership.Table
+	// ownerName returns the name owning the name, false if the name is owned by itself
+	ownerName func(name string) (string, bool)
+	mutex     sync.Mutex
+}
+
+func newOwners(opts ...Option) *owners {
+	p := &authorizePolicies{}
+	for _, o := range opts {
+		o.apply(p)
+	}
+	return &owners{
+		policies: p,
+		table:    ownership.NewTable(),
+		ownerName: func(string) (string, bool) {
+			return "", false
+		},
+	}
+}
+
+// claim checks the registration of name by the caller and claims the not owned name before the registration, so
+// concurrent callers can't both pass the check. release undoes the claim if the registration fails.
+func (o *owners) claim(ctx context.Context, name string) (identity string, release func(), err error) {
+	identity = peerid.Identity(ctx)
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	if ownerName, ok := o.ownerName(name); ok && o.table.Get(ownerName) == nil {
+		return "", nil, status.Errorf(codes.PermissionDenied, "%v can't be registered before %v", name, ownerName)
+	}
+	input := o.input(identity, name)
+	if err := o.policies.check(ctx, input); err != nil {
+		return "", nil, err
+	}
+	if _, ok := o.ownerName(name); ok || name == "" || input.Owner != "" {
+		return identity, func() {}, nil
+	}
+	o.table.Set(name, ownership.NewOwner(identity, nil))
+	return identity, func() {
+		o.mutex.Lock()
+		o.table.Set(name, nil)
+		o.mutex.Unlock()
+	}, nil
+}
+
+// registered makes identity the owner of the registered name until expirationTime
+func (o *owners) registered(name, identity string, expirationTime *timestamp.Timestamp) {
+	if _, ok := o.ownerName(name); ok {
+		return
+	}
+	o.mutex.Lock()
+	o.table.Set(name, ownership.NewOwner(identity, expirationTime))
+	o.mutex.Unlock()
+}
+
+// checkUnregister checks the unregistration of name by the caller
+func (o *owners) checkUnregister(ctx context.Context, name string) error {
+	o.mutex.Lock()
+	input := o.input(peerid.Identity(ctx), name)
+	o.mutex.Unlock()
+	return o.policies.check(ctx, input)
+}
+
+// unregistered removes the owner of the unregistered name
+func (o *owners) unregistered(name string) {
+	o.mutex.Lock()
+	o.table.Set(name, nil)
+	o.mutex.Unlock()
+}
+
+// input should be called under o.mutex
+func (o *owners) input(identity, name string) *Input {
+	input := &Input{
+		SpiffeID: identity,
+		Name:     name,
+	}
+	ownerName, ok := o.ownerName(name)
+	if !ok {
+		ownerName = name
+	}
+	if owner := o.table.Get(ownerName); owner != nil {
+		input.Owner = owner.Identity
+	}
+	return input
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authorize
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/nspolicy"
+)
+
+type authorizeNSServer struct {
+	owners *owners
+}
+
+// NewNetworkServiceRegistryServer - returns a new authorization registry.NetworkServiceRegistryServer.
+// The first caller registering a NetworkService name becomes its owner until the NetworkService is unregistered.
+// The policy NetworkService of a NetworkService (see nspolicy.PolicyName) is owned by the owner of the NetworkService
+// and can't be registered before it.
+// Register and Unregister requests are checked by the policies with Input.
+func NewNetworkServiceRegistryServer(opts ...Option) registry.NetworkServiceRegistryServer {
+	owners := newOwners(opts...)
+	owners.ownerName = nspolicy.NetworkServiceName
+	return &authorizeNSServer{
+		owners: owners,
+	}
+}
+
+func (s *authorizeNSServer) Register(ctx context.Context, ns *registry.NetworkService) (*registry.NetworkService, error) {
+	identity, release, err := s.owners.claim(ctx, ns.Name)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := next.NetworkServiceRegistryServer(ctx).Register(ctx, ns)
+	if err != nil {
+		release()
+		return nil, err
+	}
+	s.owners.registered(resp.Name, identity, nil)
+	return resp, nil
+}
+
+func (s *authorizeNSServer) Find(query *registry.NetworkServiceQuery, server registry.NetworkServiceRegistry_FindServer) error {
+	return next.NetworkServiceRegistryServer(server.Context()).Find(query, server)
+}
+
+func (s *authorizeNSServer) Unregister(ctx context.Context, ns *registry.NetworkService) (*empty.Empty, error) {
+	if err := s.owners.checkUnregister(ctx, ns.Name); err != nil {
+		return nil, err
+	}
+	resp, err := next.NetworkServiceRegistryServer(ctx).Unregister(ctx, ns)
+	if err != nil {
+		return nil, err
+	}
+	s.owners.unregistered(ns.Name)
+	return resp, nil
+}
+
+var _ registry.NetworkServiceRegistryServer = &authorizeNSServer{}
@@ -0,0 +1,83 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authorize_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/common/authorize"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+	"github.com/networkservicemesh/sdk/pkg/registry/memory"
+	"github.com/networkservicemesh/sdk/pkg/tools/nspolicy"
+)
+
+func TestAuthorizeNSServer(t *testing.T) {
+	s := next.NewNetworkServiceRegistryServer(
+		authorize.NewNetworkServiceRegistryServer(authorize.WithDefaultPolicies()),
+		memory.NewNetworkServiceRegistryServer(),
+	)
+	owner := withPeer(t, "spiffe://test.com/owner")
+	intruder := withPeer(t, "spiffe://test.com/intruder")
+
+	_, err := s.Register(context.Background(), &registry.NetworkService{Name: "ns-1"})
+	requirePermissionDenied(t, err)
+
+	_, err = s.Register(owner, &registry.NetworkService{Name: "ns-1"})
+	require.Nil(t, err)
+	_, err = s.Register(owner, &registry.NetworkService{Name: "ns-1", Payload: "IP"})
+	require.Nil(t, err)
+
+	_, err = s.Register(intruder, &registry.NetworkService{Name: "ns-1", Payload: "ETHERNET"})
+	requirePermissionDenied(t, err)
+	_, err = s.Unregister(intruder, &registry.NetworkService{Name: "ns-1"})
+	requirePermissionDenied(t, err)
+
+	_, err = s.Unregister(owner, &registry.NetworkService{Name: "ns-1"})
+	require.Nil(t, err)
+
+	_, err = s.Register(intruder, &registry.NetworkService{Name: "ns-1"})
+	require.Nil(t, err)
+}
+
+func TestAuthorizeNSServer_Policy(t *testing.T) {
+	s := next.NewNetworkServiceRegistryServer(
+		authorize.NewNetworkServiceRegistryServer(authorize.WithDefaultPolicies()),
+		memory.NewNetworkServiceRegistryServer(),
+	)
+	owner := withPeer(t, "spiffe://test.com/owner")
+	intruder := withPeer(t, "spiffe://test.com/intruder")
+	policy := &registry.NetworkService{Name: nspolicy.PolicyName("ns-1"), Payload: "{}"}
+
+	_, err := s.Register(intruder, policy)
+	requirePermissionDenied(t, err)
+
+	_, err = s.Register(owner, &registry.NetworkService{Name: "ns-1", Payload: "IP"})
+	require.Nil(t, err)
+
+	_, err = s.Register(intruder, policy)
+	requirePermissionDenied(t, err)
+	_, err = s.Register(owner, policy)
+	require.Nil(t, err)
+	_, err = s.Unregister(intruder, policy)
+	requirePermissionDenied(t, err)
+	_, err = s.Unregister(owner, policy)
+	require.Nil(t, err)
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authorize_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"math/big"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/common/authorize"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+	"github.com/networkservicemesh/sdk/pkg/registry/memory"
+)
+
+func withPeer(t *testing.T, spiffeID string) context.Context {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.Nil(t, err)
+	id, err := url.Parse(spiffeID)
+	require.Nil(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+		URIs:         []*url.URL{id},
+	}
+	certBytes, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.Nil(t, err)
+	cert, err := x509.ParseCertificate(certBytes)
+	require.Nil(t, err)
+	return peer.NewContext(context.Background(), &peer.Peer{
+		AuthInfo: credentials.TLSInfo{
+			State: tls.ConnectionState{
+				PeerCertificates: []*x509.Certificate{cert},
+			},
+		},
+	})
+}
+
+func requirePermissionDenied(t *testing.T, err error) {
+	require.NotNil(t, err)
+	s, ok := status.FromError(err)
+	require.True(t, ok, "error without error status code"+err.Error())
+	require.Equal(t, codes.PermissionDenied, s.Code())
+}
+
+func TestAuthorizeNSEServer(t *testing.T) {
+	s := next.NewNetworkServiceEndpointRegistryServer(
+		authorize.NewNetworkServiceEndpointRegistryServer(authorize.WithDefaultPolicies()),
+		memory.NewNetworkServiceEndpointRegistryServer(),
+	)
+	owner := withPeer(t, "spiffe://test.com/owner")
+	intruder := withPeer(t, "spiffe://test.com/intruder")
+
+	_, err := s.Register(context.Background(), &registry.NetworkServiceEndpoint{Name: "nse-1"})
+	requirePermissionDenied(t, err)
+
+	_, err = s.Register(owner, &registry.NetworkServiceEndpoint{Name: "nse-1"})
+	require.Nil(t, err)
+	_, err = s.Register(owner, &registry.NetworkServiceEndpoint{Name: "nse-1"})
+	require.Nil(t, err)
+
+	_, err = s.Register(intruder, &registry.NetworkServiceEndpoint{Name: "nse-1"})
+	requirePermissionDenied(t, err)
+	_, err = s.Unregister(intruder, &registry.NetworkServiceEndpoint{Name: "nse-1"})
+	requirePermissionDenied(t, err)
+
+	_, err = s.Unregister(owner, &registry.NetworkServiceEndpoint{Name: "nse-1"})
+	require.Nil(t, err)
+
+	_, err = s.Register(intruder, &registry.NetworkServiceEndpoint{Name: "nse-1"})
+	require.Nil(t, err)
+}
@@ -36,3 +36,7 @@ func getExpiredNSEs(nseMap map[string]*registry.NetworkServiceEndpoint) []*regis
 	}
 	return list
 }
+
+func isUnregistered(nse *registry.NetworkServiceEndpoint) bool {
+	return nse.ExpirationTime != nil && nse.ExpirationTime.Seconds < 0
+}
@@ -0,0 +1,63 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expire
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Metrics collects statistics of the expirations performed by NetworkServiceEndpoint expire server
+type Metrics struct {
+	expired    uint64
+	failed     uint64
+	scheduled  int64
+	totalDelay int64
+}
+
+// Expired returns the number of NetworkServiceEndpoints unregistered because of expiration
+func (m *Metrics) Expired() uint64 {
+	return atomic.LoadUint64(&m.expired)
+}
+
+// Failed returns the number of expirations that have failed to unregister NetworkServiceEndpoint
+func (m *Metrics) Failed() uint64 {
+	return atomic.LoadUint64(&m.failed)
+}
+
+// Scheduled returns the number of NetworkServiceEndpoints currently waiting for expiration
+func (m *Metrics) Scheduled() int64 {
+	return atomic.LoadInt64(&m.scheduled)
+}
+
+// TotalDelay returns the sum of delays between the expiration times and the actual expirations
+func (m *Metrics) TotalDelay() time.Duration {
+	return time.Duration(atomic.LoadInt64(&m.totalDelay))
+}
+
+func (m *Metrics) setScheduled(n int) {
+	atomic.StoreInt64(&m.scheduled, int64(n))
+}
+
+func (m *Metrics) addExpired(delay time.Duration, err error) {
+	if err != nil {
+		atomic.AddUint64(&m.failed, 1)
+		return
+	}
+	atomic.AddUint64(&m.expired, 1)
+	atomic.AddInt64(&m.totalDelay, int64(delay))
+}
@@ -24,27 +24,28 @@ import (
 
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/nspolicy"
 )
 
 type nsServer struct {
-	server     registry.NetworkServiceRegistryServer
-	nseClient  registry.NetworkServiceEndpointRegistryClient
-	once       sync.Once
-	period     time.Duration
-	monitorErr error
-	nses       map[string]*registry.NetworkServiceEndpoint
-	nsCounter  map[string]int64
-	nss        map[string]*registry.NetworkService
+	ctx         context.Context
+	server      registry.NetworkServiceRegistryServer
+	nseClient   registry.NetworkServiceEndpointRegistryClient
+	once        sync.Once
+	period      time.Duration
+	nsTimeout   time.Duration
+	monitorErr  error
+	nses        map[string]*registry.NetworkServiceEndpoint
+	nsCounter   map[string]int64
+	nss         map[string]*registry.NetworkService
+	unusedSince map[string]time.Time
 	sync.Mutex
 }
 
-func (n *nsServer) setPeriod(d time.Duration) {
-	n.period = d
-}
-
 func (n *nsServer) monitorUpdates() {
 	for {
-		c, err := n.nseClient.Find(context.Background(), &registry.NetworkServiceEndpointQuery{
+		c, err := n.nseClient.Find(n.ctx, &registry.NetworkServiceEndpointQuery{
 			NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{},
 			Watch:                  true,
 		})
@@ -55,40 +56,68 @@ func (n *nsServer) monitorUpdates() {
 		for event := range registry.ReadNetworkServiceEndpointChannel(c) {
 			nse := event
 			n.Lock()
-			_, exist := n.nses[nse.Name]
-			n.nses[nse.Name] = nse
-			if !exist {
-				for _, service := range nse.NetworkServiceNames {
-					n.nsCounter[service]++
-				}
+			n.removeNSE(nse.Name)
+			if !isUnregistered(nse) {
+				n.addNSE(nse)
 			}
 			n.Unlock()
 		}
+		if n.ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+func (n *nsServer) addNSE(nse *registry.NetworkServiceEndpoint) {
+	n.nses[nse.Name] = nse
+	for _, service := range nse.NetworkServiceNames {
+		delete(n.unusedSince, service)
+		n.nsCounter[service]++
+	}
+}
+
+func (n *nsServer) removeNSE(name string) {
+	nse, ok := n.nses[name]
+	if !ok {
+		return
+	}
+	delete(n.nses, name)
+	for _, service := range nse.NetworkServiceNames {
+		n.nsCounter[service]--
+		if n.nsCounter[service] == 0 {
+			delete(n.nsCounter, service)
+			if n.nsTimeout > 0 {
+				n.unusedSince[service] = time.Now()
+			}
+		}
 	}
 }
 
 func (n *nsServer) monitorNSEsExpiration() {
 	for {
-		var list []*registry.NetworkService
 		n.Lock()
 		for _, nse := range getExpiredNSEs(n.nses) {
-			for _, service := range nse.NetworkServiceNames {
-				n.nsCounter[service]--
-				if n.nsCounter[service] == 0 {
-					ns, ok := n.nss[service]
-					if ok {
-						list = append(list, ns)
-					}
+			n.removeNSE(nse.Name)
+		}
+		for service, since := range n.unusedSince {
+			if time.Since(since) < n.nsTimeout {
+				continue
+			}
+			delete(n.unusedSince, service)
+			// The policy NetworkService has no endpoints of its own, so it is expired together with its NetworkService
+			for _, name := range []string{service, nspolicy.PolicyName(service)} {
+				if ns, ok := n.nss[name]; ok {
+					delete(n.nss, name)
+					_, _ = n.server.Unregister(n.ctx, ns)
 				}
 			}
 		}
-		for _, ns := range list {
-			delete(n.nsCounter, ns.Name)
-			delete(n.nss, ns.Name)
-			_, _ = n.server.Unregister(context.Background(), ns)
-		}
 		n.Unlock()
-		<-time.After(n.period)
+		select {
+		case <-n.ctx.Done():
+			return
+		case <-time.After(n.period):
+		}
 	}
 }
 
@@ -106,13 +135,19 @@ func (n *nsServer) Register(ctx context.Context, request *registry.NetworkServic
 	if n.monitorErr != nil {
 		return nil, n.monitorErr
 	}
+	// Registration is done under the lock, so the expiration monitor can not unregister the NetworkService between
+	// it is stored and tracked
+	n.Lock()
+	defer n.Unlock()
 	r, err := n.server.Register(ctx, request)
 	if err != nil {
 		return nil, err
 	}
-	n.Lock()
 	n.nss[request.Name] = r
-	n.Unlock()
+	if _, ok := n.unusedSince[request.Name]; !ok && n.nsTimeout > 0 && n.nsCounter[request.Name] == 0 && !isPolicy(request.Name) {
+		// Give the endpoints registered together with the NetworkService one period to come from the watch
+		n.unusedSince[request.Name] = time.Now().Add(n.period)
+	}
 	return r, nil
 }
 
@@ -130,24 +165,39 @@ func (n *nsServer) Unregister(ctx context.Context, request *registry.NetworkServ
 	n.Lock()
 	defer n.Unlock()
 	if n.nsCounter[request.Name] == 0 {
+		delete(n.nss, request.Name)
+		delete(n.unusedSince, request.Name)
 		return n.server.Unregister(ctx, request)
 	}
 	return new(empty.Empty), errors.New("can not delete ns cause of already in use")
 }
 
-// NewNetworkServiceServer wraps passed NetworkServiceRegistryServer and monitor NetworkServiceEndpoints via passed NetworkServiceEndpointRegistryClient
-func NewNetworkServiceServer(s registry.NetworkServiceRegistryServer, nseClient registry.NetworkServiceEndpointRegistryClient, options ...Option) registry.NetworkServiceRegistryServer {
+// NewNetworkServiceServer wraps passed NetworkServiceRegistryServer and monitor NetworkServiceEndpoints via passed NetworkServiceEndpointRegistryClient.
+// If WithNetworkServiceTimeout is set, a NetworkService is unregistered once it has had no registered endpoints for the
+// timeout, otherwise the NetworkServices are never expired.
+// The timeout starts when the last endpoint of the NetworkService expires or is unregistered, or one period after
+// the NetworkService is registered without endpoints.
+// Monitoring stops when ctx is done.
+func NewNetworkServiceServer(ctx context.Context, s registry.NetworkServiceRegistryServer, nseClient registry.NetworkServiceEndpointRegistryClient, options ...NSOption) registry.NetworkServiceRegistryServer {
 	r := &nsServer{
-		server:    s,
-		nseClient: nseClient,
-		nsCounter: map[string]int64{},
-		nss:       map[string]*registry.NetworkService{},
-		nses:      map[string]*registry.NetworkServiceEndpoint{},
+		ctx:         ctx,
+		server:      s,
+		nseClient:   nseClient,
+		period:      defaultPeriod,
+		nsCounter:   map[string]int64{},
+		nss:         map[string]*registry.NetworkService{},
+		nses:        map[string]*registry.NetworkServiceEndpoint{},
+		unusedSince: map[string]time.Time{},
 	}
 
 	for _, o := range options {
-		o.apply(r)
+		o(r)
 	}
 
 	return r
 }
+
+func isPolicy(name string) bool {
+	_, ok := nspolicy.NetworkServiceName(name)
+	return ok
+}
@@ -30,6 +30,7 @@ import (
 	"github.com/networkservicemesh/sdk/pkg/registry/core/adapters"
 	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
 	"github.com/networkservicemesh/sdk/pkg/registry/memory"
+	"github.com/networkservicemesh/sdk/pkg/tools/nspolicy"
 )
 
 func TestNewNetworkServiceRegistryServer(t *testing.T) {
@@ -47,8 +48,10 @@ func TestNewNetworkServiceRegistryServer(t *testing.T) {
 	})
 	require.Nil(t, err)
 	nseClient := adapters.NetworkServiceEndpointServerToClient(nseMem)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
 	nsMem := memory.NewNetworkServiceRegistryServer()
-	s := expire.NewNetworkServiceServer(nsMem, nseClient, expire.WithPeriod(testPeriod))
+	s := expire.NewNetworkServiceServer(ctx, nsMem, nseClient, expire.WithPeriod(testPeriod), expire.WithNetworkServiceTimeout(testPeriod))
 	_, err = s.Register(context.Background(), &registry.NetworkService{
 		Name: "IP terminator",
 	})
@@ -60,7 +63,7 @@ func TestNewNetworkServiceRegistryServer(t *testing.T) {
 	require.Nil(t, err)
 	list := registry.ReadNetworkServiceList(stream)
 	require.NotEmpty(t, list)
-	<-time.After(testPeriod * 3)
+	<-time.After(testPeriod * 5)
 	stream, err = nsClient.Find(context.Background(), &registry.NetworkServiceQuery{
 		NetworkService: &registry.NetworkService{},
 	})
@@ -84,8 +87,10 @@ func TestNewNetworkServiceRegistryServer_NSEUnregister(t *testing.T) {
 	})
 	require.Nil(t, err)
 	nseClient := adapters.NetworkServiceEndpointServerToClient(nseMem)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
 	nsMem := memory.NewNetworkServiceRegistryServer()
-	s := expire.NewNetworkServiceServer(nsMem, nseClient, expire.WithPeriod(testPeriod))
+	s := expire.NewNetworkServiceServer(ctx, nsMem, nseClient, expire.WithPeriod(testPeriod), expire.WithNetworkServiceTimeout(testPeriod))
 	_, err = s.Register(context.Background(), &registry.NetworkService{
 		Name: "IP terminator",
 	})
@@ -103,7 +108,7 @@ func TestNewNetworkServiceRegistryServer_NSEUnregister(t *testing.T) {
 		NetworkServiceNames: []string{"IP terminator"},
 	})
 	require.Nil(t, err)
-	<-time.After(testPeriod * 3)
+	<-time.After(testPeriod * 5)
 	stream, err = nsClient.Find(context.Background(), &registry.NetworkServiceQuery{
 		NetworkService: &registry.NetworkService{},
 	})
@@ -111,3 +116,140 @@ func TestNewNetworkServiceRegistryServer_NSEUnregister(t *testing.T) {
 	list = registry.ReadNetworkServiceList(stream)
 	require.Empty(t, list)
 }
+
+func TestNewNetworkServiceRegistryServer_NetworkServiceTimeout(t *testing.T) {
+	nseMem := next.NewNetworkServiceEndpointRegistryServer(
+		memory.NewNetworkServiceEndpointRegistryServer(),
+	)
+	_, err := nseMem.Register(context.Background(), &registry.NetworkServiceEndpoint{
+		Name:                "nse-1",
+		NetworkServiceNames: []string{"IP terminator"},
+	})
+	require.Nil(t, err)
+	nseClient := adapters.NetworkServiceEndpointServerToClient(nseMem)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	nsMem := memory.NewNetworkServiceRegistryServer()
+	s := expire.NewNetworkServiceServer(ctx, nsMem, nseClient, expire.WithPeriod(testPeriod), expire.WithNetworkServiceTimeout(testPeriod*4))
+	_, err = s.Register(context.Background(), &registry.NetworkService{
+		Name: "IP terminator",
+	})
+	require.Nil(t, err)
+	nsClient := adapters.NetworkServiceServerToClient(s)
+	unregistered := make(chan *registry.NetworkService, 1)
+	watch, err := nsClient.Find(memory.WithUnregisterNotify(ctx, func(ns *registry.NetworkService) {
+		unregistered <- ns
+	}), &registry.NetworkServiceQuery{
+		NetworkService: &registry.NetworkService{},
+		Watch:          true,
+	})
+	require.Nil(t, err)
+	require.Equal(t, "IP terminator", (<-registry.ReadNetworkServiceChannel(watch)).Name)
+	<-time.After(testPeriod * 2)
+	_, err = nseClient.Unregister(context.Background(), &registry.NetworkServiceEndpoint{
+		Name:                "nse-1",
+		NetworkServiceNames: []string{"IP terminator"},
+	})
+	require.Nil(t, err)
+	<-time.After(testPeriod * 2)
+	stream, err := nsClient.Find(context.Background(), &registry.NetworkServiceQuery{
+		NetworkService: &registry.NetworkService{},
+	})
+	require.Nil(t, err)
+	require.NotEmpty(t, registry.ReadNetworkServiceList(stream))
+	select {
+	case ns := <-unregistered:
+		require.Equal(t, "IP terminator", ns.Name)
+	case <-time.After(testPeriod * 6):
+		require.FailNow(t, "watcher is not notified about removed network service")
+	}
+	stream, err = nsClient.Find(context.Background(), &registry.NetworkServiceQuery{
+		NetworkService: &registry.NetworkService{},
+	})
+	require.Nil(t, err)
+	require.Empty(t, registry.ReadNetworkServiceList(stream))
+}
+
+func TestNewNetworkServiceRegistryServer_NetworkServiceWithoutEndpoints(t *testing.T) {
+	nseClient := adapters.NetworkServiceEndpointServerToClient(memory.NewNetworkServiceEndpointRegistryServer())
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s := expire.NewNetworkServiceServer(ctx, memory.NewNetworkServiceRegistryServer(), nseClient, expire.WithPeriod(testPeriod), expire.WithNetworkServiceTimeout(testPeriod*2))
+	_, err := s.Register(context.Background(), &registry.NetworkService{
+		Name: "IP terminator",
+	})
+	require.Nil(t, err)
+	nsClient := adapters.NetworkServiceServerToClient(s)
+	stream, err := nsClient.Find(context.Background(), &registry.NetworkServiceQuery{
+		NetworkService: &registry.NetworkService{},
+	})
+	require.Nil(t, err)
+	require.NotEmpty(t, registry.ReadNetworkServiceList(stream))
+	<-time.After(testPeriod * 6)
+	stream, err = nsClient.Find(context.Background(), &registry.NetworkServiceQuery{
+		NetworkService: &registry.NetworkService{},
+	})
+	require.Nil(t, err)
+	require.Empty(t, registry.ReadNetworkServiceList(stream))
+}
+
+func TestNewNetworkServiceRegistryServer_NoNetworkServiceTimeout(t *testing.T) {
+	nseMem := next.NewNetworkServiceEndpointRegistryServer(
+		memory.NewNetworkServiceEndpointRegistryServer(),
+	)
+	_, err := nseMem.Register(context.Background(), &registry.NetworkServiceEndpoint{
+		Name:                "nse-1",
+		NetworkServiceNames: []string{"IP terminator"},
+	})
+	require.Nil(t, err)
+	nseClient := adapters.NetworkServiceEndpointServerToClient(nseMem)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s := expire.NewNetworkServiceServer(ctx, memory.NewNetworkServiceRegistryServer(), nseClient, expire.WithPeriod(testPeriod))
+	_, err = s.Register(context.Background(), &registry.NetworkService{
+		Name: "IP terminator",
+	})
+	require.Nil(t, err)
+	<-time.After(testPeriod * 2)
+	_, err = nseClient.Unregister(context.Background(), &registry.NetworkServiceEndpoint{
+		Name:                "nse-1",
+		NetworkServiceNames: []string{"IP terminator"},
+	})
+	require.Nil(t, err)
+	<-time.After(testPeriod * 4)
+	nsClient := adapters.NetworkServiceServerToClient(s)
+	stream, err := nsClient.Find(context.Background(), &registry.NetworkServiceQuery{
+		NetworkService: &registry.NetworkService{},
+	})
+	require.Nil(t, err)
+	require.NotEmpty(t, registry.ReadNetworkServiceList(stream))
+}
+
+func TestNewNetworkServiceRegistryServer_Policy(t *testing.T) {
+	nseClient := adapters.NetworkServiceEndpointServerToClient(memory.NewNetworkServiceEndpointRegistryServer())
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s := expire.NewNetworkServiceServer(ctx, memory.NewNetworkServiceRegistryServer(), nseClient, expire.WithPeriod(testPeriod), expire.WithNetworkServiceTimeout(testPeriod*2))
+	_, err := s.Register(context.Background(), &registry.NetworkService{
+		Name: "IP terminator",
+	})
+	require.Nil(t, err)
+	<-time.After(testPeriod * 2)
+	_, err = s.Register(context.Background(), &registry.NetworkService{
+		Name: nspolicy.PolicyName("IP terminator"),
+	})
+	require.Nil(t, err)
+	nsClient := adapters.NetworkServiceServerToClient(s)
+	stream, err := nsClient.Find(context.Background(), &registry.NetworkServiceQuery{
+		NetworkService: &registry.NetworkService{},
+	})
+	require.Nil(t, err)
+	require.Len(t, registry.ReadNetworkServiceList(stream), 2)
+	// The policy is expired with its network service, not by its own registration time
+	<-time.After(testPeriod * 6)
+	stream, err = nsClient.Find(context.Background(), &registry.NetworkServiceQuery{
+		NetworkService: &registry.NetworkService{},
+	})
+	require.Nil(t, err)
+	require.Empty(t, registry.ReadNetworkServiceList(stream))
+}
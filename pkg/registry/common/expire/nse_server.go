@@ -17,6 +17,7 @@
 package expire
 
 import (
+	"container/heap"
 	"context"
 	"sync"
 	"time"
@@ -25,27 +26,35 @@ import (
 	"github.com/networkservicemesh/api/pkg/api/registry"
 )
 
-type nseServer struct {
-	nses      map[string]*registry.NetworkServiceEndpoint
-	nsesMutex sync.Mutex
-	period    time.Duration
-	once      sync.Once
-	server    registry.NetworkServiceEndpointRegistryServer
-}
+const (
+	minRetryDelay = time.Millisecond * 100
+	maxRetryDelay = defaultPeriod
+)
 
-func (n *nseServer) setPeriod(d time.Duration) {
-	n.period = d
+type nseServer struct {
+	ctx      context.Context
+	server   registry.NetworkServiceEndpointRegistryServer
+	queue    expirationQueue
+	items    map[string]*expirationItem
+	expiring map[string]chan struct{}
+	updated  chan struct{}
+	metrics  *Metrics
+	sync.Mutex
 }
 
 func (n *nseServer) Register(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
-	n.once.Do(n.monitor)
+	// Registration is done under the lock after the running expiration of the endpoint, so the monitor can not
+	// unregister the refreshed endpoint by its outdated expiration item
+	n.Lock()
+	defer n.Unlock()
+	if err := n.waitExpiration(ctx, nse.Name); err != nil {
+		return nil, err
+	}
 	r, err := n.server.Register(ctx, nse)
 	if err != nil {
 		return nil, err
 	}
-	n.nsesMutex.Lock()
-	n.nses[nse.Name] = r
-	n.nsesMutex.Unlock()
+	n.schedule(r)
 	return r, nil
 }
 
@@ -54,42 +63,164 @@ func (n *nseServer) Find(query *registry.NetworkServiceEndpointQuery, s registry
 }
 
 func (n *nseServer) Unregister(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*empty.Empty, error) {
+	n.Lock()
+	defer n.Unlock()
+	if err := n.waitExpiration(ctx, nse.Name); err != nil {
+		return nil, err
+	}
 	resp, err := n.server.Unregister(ctx, nse)
 	if err != nil {
 		return nil, err
 	}
-	n.nsesMutex.Lock()
-	delete(n.nses, nse.Name)
-	n.nsesMutex.Unlock()
+	n.remove(nse.Name)
 	return resp, nil
 }
 
+func (n *nseServer) schedule(nse *registry.NetworkServiceEndpoint) {
+	n.remove(nse.Name)
+	if nse.ExpirationTime == nil {
+		return
+	}
+	item := &expirationItem{
+		nse:      nse,
+		deadline: time.Unix(nse.ExpirationTime.Seconds, int64(nse.ExpirationTime.Nanos)),
+	}
+	n.items[nse.Name] = item
+	heap.Push(&n.queue, item)
+	n.metrics.setScheduled(n.queue.Len())
+	if item.index == 0 {
+		n.notify()
+	}
+}
+
+func (n *nseServer) remove(name string) {
+	item, ok := n.items[name]
+	if !ok {
+		return
+	}
+	delete(n.items, name)
+	heap.Remove(&n.queue, item.index)
+	n.metrics.setScheduled(n.queue.Len())
+}
+
+func (n *nseServer) notify() {
+	select {
+	case n.updated <- struct{}{}:
+	default:
+	}
+}
+
+// waitExpiration waits under the lock for the running expiration of the endpoint with the name to complete
+func (n *nseServer) waitExpiration(ctx context.Context, name string) error {
+	for {
+		done, ok := n.expiring[name]
+		if !ok {
+			return nil
+		}
+		n.Unlock()
+		select {
+		case <-ctx.Done():
+			n.Lock()
+			return ctx.Err()
+		case <-done:
+		}
+		n.Lock()
+	}
+}
+
+// expireFirst unregisters the earliest endpoint if its deadline has passed, otherwise returns the time left to it.
+// The endpoint is popped under the lock and unregistered outside it, Register and Unregister of the endpoint wait for
+// the expiration to complete. A failed expiration is retried with backoff unless the endpoint has been scheduled again.
+func (n *nseServer) expireFirst() (bool, time.Duration) {
+	n.Lock()
+	if n.queue.Len() == 0 {
+		n.Unlock()
+		return false, -1
+	}
+	if d := time.Until(n.queue[0].deadline); d > 0 {
+		n.Unlock()
+		return false, d
+	}
+	item := heap.Pop(&n.queue).(*expirationItem)
+	delete(n.items, item.nse.Name)
+	n.metrics.setScheduled(n.queue.Len())
+	done := make(chan struct{})
+	n.expiring[item.nse.Name] = done
+	n.Unlock()
+
+	_, err := n.server.Unregister(n.ctx, item.nse)
+	n.metrics.addExpired(time.Since(item.deadline), err)
+
+	n.Lock()
+	defer n.Unlock()
+	delete(n.expiring, item.nse.Name)
+	close(done)
+	if _, ok := n.items[item.nse.Name]; err != nil && !ok && n.ctx.Err() == nil {
+		item.retries++
+		item.deadline = time.Now().Add(retryDelay(item.retries))
+		n.items[item.nse.Name] = item
+		heap.Push(&n.queue, item)
+		n.metrics.setScheduled(n.queue.Len())
+	}
+	return true, 0
+}
+
+func retryDelay(retries int) time.Duration {
+	d := minRetryDelay
+	for i := 1; i < retries && d < maxRetryDelay; i++ {
+		d *= 2
+	}
+	if d > maxRetryDelay {
+		return maxRetryDelay
+	}
+	return d
+}
+
 func (n *nseServer) monitor() {
-	go func() {
-		for {
-			n.nsesMutex.Lock()
-			for _, nse := range getExpiredNSEs(n.nses) {
-				delete(n.nses, nse.Name)
-				_, _ = n.server.Unregister(context.Background(), nse)
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		expired, d := n.expireFirst()
+		if expired {
+			continue
+		}
+		if !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
 			}
-			n.nsesMutex.Unlock()
-
-			<-time.After(n.period)
 		}
-	}()
+		var timerCh <-chan time.Time
+		if d > 0 {
+			timer.Reset(d)
+			timerCh = timer.C
+		}
+		select {
+		case <-n.ctx.Done():
+			return
+		case <-n.updated:
+		case <-timerCh:
+		}
+	}
 }
 
-// NewNetworkServiceEndpointRegistryServer wraps passed NetworkServiceEndpointRegistryServer and monitor Network service endpoints
-func NewNetworkServiceEndpointRegistryServer(server registry.NetworkServiceEndpointRegistryServer, options ...Option) registry.NetworkServiceEndpointRegistryServer {
+// NewNetworkServiceEndpointRegistryServer wraps passed NetworkServiceEndpointRegistryServer and unregisters Network service endpoints
+// exactly at their expiration time. Expiration stops when ctx is done.
+func NewNetworkServiceEndpointRegistryServer(ctx context.Context, server registry.NetworkServiceEndpointRegistryServer, options ...NSEOption) registry.NetworkServiceEndpointRegistryServer {
 	r := &nseServer{
-		server: server,
-		period: defaultPeriod,
-		nses:   map[string]*registry.NetworkServiceEndpoint{},
+		ctx:      ctx,
+		server:   server,
+		items:    map[string]*expirationItem{},
+		expiring: map[string]chan struct{}{},
+		updated:  make(chan struct{}, 1),
+		metrics:  &Metrics{},
 	}
 
 	for _, o := range options {
-		o.apply(r)
+		o(r)
 	}
 
+	go r.monitor()
+
 	return r
 }
@@ -18,20 +18,28 @@ package expire_test
 
 import (
 	"context"
+	"errors"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/networkservicemesh/api/pkg/api/registry"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
 
 	"github.com/networkservicemesh/sdk/pkg/registry/common/expire"
 	"github.com/networkservicemesh/sdk/pkg/registry/core/adapters"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
 	"github.com/networkservicemesh/sdk/pkg/registry/memory"
 )
 
 func TestNewNetworkServiceEndpointRegistryServer(t *testing.T) {
-	s := expire.NewNetworkServiceEndpointRegistryServer(memory.NewNetworkServiceEndpointRegistryServer(), expire.WithPeriod(testPeriod))
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s := expire.NewNetworkServiceEndpointRegistryServer(ctx, memory.NewNetworkServiceEndpointRegistryServer())
 	expiration := time.Now().Add(testPeriod * 2)
 	_, err := s.Register(context.Background(), &registry.NetworkServiceEndpoint{
 		ExpirationTime: &timestamp.Timestamp{
@@ -55,3 +63,132 @@ func TestNewNetworkServiceEndpointRegistryServer(t *testing.T) {
 	list = registry.ReadNetworkServiceEndpointList(stream)
 	require.Empty(t, list)
 }
+
+func TestNewNetworkServiceEndpointRegistryServer_ExpirationOrder(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	metrics := new(expire.Metrics)
+	s := expire.NewNetworkServiceEndpointRegistryServer(ctx, memory.NewNetworkServiceEndpointRegistryServer(), expire.WithMetrics(metrics))
+	now := time.Now()
+	for i, name := range []string{"nse-3", "nse-1", "nse-2"} {
+		expiration := now.Add(testPeriod * time.Duration(2*(i+1)))
+		_, err := s.Register(context.Background(), &registry.NetworkServiceEndpoint{
+			Name: name,
+			ExpirationTime: &timestamp.Timestamp{
+				Seconds: expiration.Unix(),
+				Nanos:   int32(expiration.Nanosecond()),
+			},
+		})
+		require.Nil(t, err)
+	}
+	require.EqualValues(t, 3, metrics.Scheduled())
+	_, err := s.Unregister(context.Background(), &registry.NetworkServiceEndpoint{Name: "nse-1"})
+	require.Nil(t, err)
+	require.EqualValues(t, 2, metrics.Scheduled())
+
+	c := adapters.NetworkServiceEndpointServerToClient(s)
+	find := func() []*registry.NetworkServiceEndpoint {
+		stream, findErr := c.Find(context.Background(), &registry.NetworkServiceEndpointQuery{
+			NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{},
+		})
+		require.Nil(t, findErr)
+		return registry.ReadNetworkServiceEndpointList(stream)
+	}
+	require.Eventually(t, func() bool {
+		return len(find()) == 1
+	}, testPeriod*8, testPeriod/10)
+	require.Equal(t, "nse-2", find()[0].Name)
+	require.Eventually(t, func() bool {
+		return len(find()) == 0
+	}, testPeriod*8, testPeriod/10)
+	require.EqualValues(t, 2, metrics.Expired())
+	require.EqualValues(t, 0, metrics.Failed())
+	require.EqualValues(t, 0, metrics.Scheduled())
+	require.Less(t, int64(metrics.TotalDelay()), int64(testPeriod*2))
+}
+
+func TestNewNetworkServiceEndpointRegistryServer_Refresh(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s := expire.NewNetworkServiceEndpointRegistryServer(ctx, memory.NewNetworkServiceEndpointRegistryServer())
+	for i := 0; i < 20; i++ {
+		expiration := time.Now().Add(testPeriod / 5)
+		_, err := s.Register(context.Background(), &registry.NetworkServiceEndpoint{
+			Name: "nse-1",
+			ExpirationTime: &timestamp.Timestamp{
+				Seconds: expiration.Unix(),
+				Nanos:   int32(expiration.Nanosecond()),
+			},
+		})
+		require.Nil(t, err)
+		<-time.After(testPeriod / 10)
+	}
+	expiration := time.Now().Add(time.Hour)
+	_, err := s.Register(context.Background(), &registry.NetworkServiceEndpoint{
+		Name: "nse-1",
+		ExpirationTime: &timestamp.Timestamp{
+			Seconds: expiration.Unix(),
+			Nanos:   int32(expiration.Nanosecond()),
+		},
+	})
+	require.Nil(t, err)
+	<-time.After(testPeriod)
+	c := adapters.NetworkServiceEndpointServerToClient(s)
+	stream, err := c.Find(context.Background(), &registry.NetworkServiceEndpointQuery{
+		NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{},
+	})
+	require.Nil(t, err)
+	require.Len(t, registry.ReadNetworkServiceEndpointList(stream), 1)
+}
+
+type failingUnregisterServer struct {
+	failures int32
+}
+
+func (s *failingUnregisterServer) Register(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	return next.NetworkServiceEndpointRegistryServer(ctx).Register(ctx, nse)
+}
+
+func (s *failingUnregisterServer) Find(query *registry.NetworkServiceEndpointQuery, server registry.NetworkServiceEndpointRegistry_FindServer) error {
+	return next.NetworkServiceEndpointRegistryServer(server.Context()).Find(query, server)
+}
+
+func (s *failingUnregisterServer) Unregister(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*empty.Empty, error) {
+	if atomic.AddInt32(&s.failures, -1) >= 0 {
+		return nil, errors.New("unregister failed")
+	}
+	return next.NetworkServiceEndpointRegistryServer(ctx).Unregister(ctx, nse)
+}
+
+func TestNewNetworkServiceEndpointRegistryServer_RetryFailedExpiration(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	metrics := new(expire.Metrics)
+	s := expire.NewNetworkServiceEndpointRegistryServer(ctx, next.NewNetworkServiceEndpointRegistryServer(
+		&failingUnregisterServer{failures: 2},
+		memory.NewNetworkServiceEndpointRegistryServer(),
+	), expire.WithMetrics(metrics))
+	expiration := time.Now().Add(testPeriod)
+	_, err := s.Register(context.Background(), &registry.NetworkServiceEndpoint{
+		Name: "nse-1",
+		ExpirationTime: &timestamp.Timestamp{
+			Seconds: expiration.Unix(),
+			Nanos:   int32(expiration.Nanosecond()),
+		},
+	})
+	require.Nil(t, err)
+	c := adapters.NetworkServiceEndpointServerToClient(s)
+	require.Eventually(t, func() bool {
+		stream, findErr := c.Find(context.Background(), &registry.NetworkServiceEndpointQuery{
+			NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{},
+		})
+		require.Nil(t, findErr)
+		return len(registry.ReadNetworkServiceEndpointList(stream)) == 0
+	}, testPeriod*20, testPeriod/10)
+	require.EqualValues(t, 1, metrics.Expired())
+	require.EqualValues(t, 2, metrics.Failed())
+	require.EqualValues(t, 0, metrics.Scheduled())
+}
@@ -18,24 +18,31 @@ package expire
 
 import "time"
 
-type configurable interface {
-	setPeriod(time.Duration)
-}
+// NSOption is NewNetworkServiceServer configuration option
+type NSOption func(*nsServer)
 
-// Option is expire registry configuration option
-type Option interface {
-	apply(configurable)
-}
+// NSEOption is NewNetworkServiceEndpointRegistryServer configuration option
+type NSEOption func(*nseServer)
 
-type applierFunc func(configurable)
+// WithPeriod sets specific period to checking expiration.
+// NewNetworkServiceEndpointRegistryServer expires endpoints at their exact expiration time, so it has no such option.
+func WithPeriod(duration time.Duration) NSOption {
+	return func(n *nsServer) {
+		n.period = duration
+	}
+}
 
-func (f applierFunc) apply(c configurable) {
-	f(c)
+// WithNetworkServiceTimeout sets how long a NetworkService may stay without registered endpoints before it is unregistered,
+// the NetworkServices are not expired by default.
+func WithNetworkServiceTimeout(duration time.Duration) NSOption {
+	return func(n *nsServer) {
+		n.nsTimeout = duration
+	}
 }
 
-// WithPeriod sets specific period to checking expiration
-func WithPeriod(duration time.Duration) Option {
-	return applierFunc(func(c configurable) {
-		c.setPeriod(duration)
-	})
+// WithMetrics sets Metrics to collect statistics of the performed expirations.
+func WithMetrics(m *Metrics) NSEOption {
+	return func(n *nseServer) {
+		n.metrics = m
+	}
 }
@@ -0,0 +1,63 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expire
+
+import (
+	"time"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+)
+
+type expirationItem struct {
+	nse      *registry.NetworkServiceEndpoint
+	deadline time.Time
+	retries  int
+	index    int
+}
+
+// expirationQueue is a min-heap of expiration items ordered by deadline, it implements heap.Interface
+type expirationQueue []*expirationItem
+
+func (q expirationQueue) Len() int {
+	return len(q)
+}
+
+func (q expirationQueue) Less(i, j int) bool {
+	return q[i].deadline.Before(q[j].deadline)
+}
+
+func (q expirationQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+
+func (q *expirationQueue) Push(x interface{}) {
+	item := x.(*expirationItem)
+	item.index = len(*q)
+	*q = append(*q, item)
+}
+
+func (q *expirationQueue) Pop() interface{} {
+	old := *q
+	n := len(old)
+	item := old[n-1]
+	old[n-1] = nil
+	item.index = -1
+	*q = old[:n-1]
+	return item
+}
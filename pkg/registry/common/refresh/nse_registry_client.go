@@ -21,48 +21,138 @@ import (
 	"sync"
 	"time"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/networkservicemesh/api/pkg/api/registry"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 
 	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
 )
 
 type refreshNSEClient struct {
-	client     registry.NetworkServiceEndpointRegistryClient
-	nsesMutex  sync.Mutex
-	nses       map[string]func()
-	retryDelay time.Duration
+	client         registry.NetworkServiceEndpointRegistryClient
+	nsesMutex      sync.Mutex
+	nses           map[string]func()
+	retryDelay     time.Duration
+	maxRetryDelay  time.Duration
+	statusCallback StatusCallback
 }
 
 func (c *refreshNSEClient) setRetryPeriod(p time.Duration) {
 	c.retryDelay = p
 }
 
-func (c *refreshNSEClient) startRefresh(ctx context.Context, nse *registry.NetworkServiceEndpoint) {
-	t := time.Unix(nse.ExpirationTime.Seconds, int64(nse.ExpirationTime.Nanos))
+func (c *refreshNSEClient) setMaxRetryPeriod(p time.Duration) {
+	c.maxRetryDelay = p
+}
+
+func (c *refreshNSEClient) setStatusCallback(callback StatusCallback) {
+	c.statusCallback = callback
+}
+
+func (c *refreshNSEClient) nextRetryDelay(delay time.Duration) time.Duration {
+	if c.maxRetryDelay <= delay {
+		return delay
+	}
+	if delay *= 2; delay > c.maxRetryDelay {
+		return c.maxRetryDelay
+	}
+	return delay
+}
+
+func (c *refreshNSEClient) startRefresh(ctx context.Context, registration, nse *registry.NetworkServiceEndpoint) {
+	// registration and nse are owned by the refresh goroutine, each Register gets its own copy
+	registration = proto.Clone(registration).(*registry.NetworkServiceEndpoint)
+	nse = proto.Clone(nse).(*registry.NetworkServiceEndpoint)
+	t := toTime(nse.ExpirationTime)
 	delta := time.Until(t)
 	go func() {
+		currentStatus := StatusRegistered
+		setStatus := func(s Status, err error) {
+			if s == currentStatus {
+				return
+			}
+			currentStatus = s
+			if c.statusCallback != nil {
+				c.statusCallback(proto.Clone(nse).(*registry.NetworkServiceEndpoint), s, err)
+			}
+		}
+		delay := 2 * delta / 3
+		retryDelay := c.retryDelay
+		lost := false
 		for {
 			select {
 			case <-ctx.Done():
 				return
-			case <-time.After(2 * time.Until(t) / 3):
-				t1 := time.Now().Add(delta)
-				nse.ExpirationTime.Seconds = t1.Unix()
-				nse.ExpirationTime.Nanos = int32(t1.Nanosecond())
-				var err error
-				nse, err = c.client.Register(ctx, nse)
-				if err != nil {
-					<-time.After(c.retryDelay)
-					continue
+			case <-time.After(delay):
+			}
+			request := proto.Clone(nse).(*registry.NetworkServiceEndpoint)
+			// Refreshes are marked for the registry to tell them from registrations, the re-registration is not
+			requestCtx := metadata.AppendToOutgoingContext(ctx, MetadataKey, "true")
+			if lost {
+				requestCtx = ctx
+				// The registry has lost the NSE, so it is registered again from the originally registered value but
+				// under the name it is known to the NSE owner by
+				request = proto.Clone(registration).(*registry.NetworkServiceEndpoint)
+				request.Name = nse.Name
+			}
+			t1 := time.Now().Add(delta)
+			request.ExpirationTime = &timestamp.Timestamp{
+				Seconds: t1.Unix(),
+				Nanos:   int32(t1.Nanosecond()),
+			}
+			resp, err := c.client.Register(requestCtx, request)
+			if ctx.Err() != nil {
+				return
+			}
+			switch {
+			case err == nil:
+				if resp.Name != nse.Name && !c.rename(ctx, nse.Name, resp.Name) {
+					return
+				}
+				nse = proto.Clone(resp).(*registry.NetworkServiceEndpoint)
+				if nse.ExpirationTime != nil {
+					t1 = toTime(nse.ExpirationTime)
 				}
 				t = t1
+				lost = false
+				retryDelay = c.retryDelay
+				setStatus(StatusRegistered, nil)
+				delay = 2 * time.Until(t) / 3
+			case status.Code(err) == codes.NotFound && !lost:
+				lost = true
+				setStatus(StatusLost, err)
+				delay = 0
+			default:
+				setStatus(StatusRetrying, err)
+				delay = retryDelay
+				retryDelay = c.nextRetryDelay(retryDelay)
 			}
 		}
 	}()
 }
 
+// rename moves the refresh of the NSE with oldName to newName, it returns false if the refresh has been cancelled
+func (c *refreshNSEClient) rename(ctx context.Context, oldName, newName string) bool {
+	c.nsesMutex.Lock()
+	defer c.nsesMutex.Unlock()
+	// Refreshes are cancelled only under nsesMutex, so the refresh is not cancelled yet if ctx is not done here and
+	// c.nses[oldName] is its cancel
+	if ctx.Err() != nil {
+		return false
+	}
+	if cancel, ok := c.nses[newName]; ok {
+		cancel()
+	}
+	c.nses[newName] = c.nses[oldName]
+	delete(c.nses, oldName)
+	return true
+}
+
 func (c *refreshNSEClient) Register(ctx context.Context, in *registry.NetworkServiceEndpoint, opts ...grpc.CallOption) (*registry.NetworkServiceEndpoint, error) {
 	resp, err := next.NetworkServiceEndpointRegistryClient(ctx).Register(ctx, in, opts...)
 	if err != nil {
@@ -70,9 +160,16 @@ func (c *refreshNSEClient) Register(ctx context.Context, in *registry.NetworkSer
 	}
 	c.nsesMutex.Lock()
 	defer c.nsesMutex.Unlock()
+	if cancel, ok := c.nses[resp.Name]; ok {
+		cancel()
+		delete(c.nses, resp.Name)
+	}
+	if resp.ExpirationTime == nil {
+		return resp, nil
+	}
 	ctx, cancel := context.WithCancel(context.Background())
 	c.nses[resp.Name] = cancel
-	c.startRefresh(ctx, resp)
+	c.startRefresh(ctx, in, resp)
 	return resp, err
 }
 
@@ -95,7 +192,9 @@ func (c *refreshNSEClient) Unregister(ctx context.Context, in *registry.NetworkS
 	return resp, nil
 }
 
-// NewNetworkServiceEndpointRegistryClient creates new NetworkServiceEndpointRegistryClient that will refresh expiration time for registered NSEs
+// NewNetworkServiceEndpointRegistryClient creates new NetworkServiceEndpointRegistryClient that will refresh expiration time for registered NSEs.
+// The refreshes are sent with MetadataKey gRPC metadata. If the registry answers NotFound to a refresh, NSE is immediately
+// registered again from the originally registered value with the name from the last registry response.
 func NewNetworkServiceEndpointRegistryClient(client registry.NetworkServiceEndpointRegistryClient, options ...Option) registry.NetworkServiceEndpointRegistryClient {
 	c := &refreshNSEClient{
 		client:     client,
@@ -109,3 +208,7 @@ func NewNetworkServiceEndpointRegistryClient(client registry.NetworkServiceEndpo
 
 	return c
 }
+
+func toTime(t *timestamp.Timestamp) time.Time {
+	return time.Unix(t.Seconds, int64(t.Nanos))
+}
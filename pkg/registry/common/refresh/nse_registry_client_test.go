@@ -18,6 +18,7 @@ package refresh_test
 
 import (
 	"context"
+	"errors"
 	"sync"
 	"testing"
 	"time"
@@ -28,8 +29,11 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/goleak"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/networkservicemesh/sdk/pkg/registry/common/refresh"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
 )
 
 type testNSEClient struct {
@@ -72,3 +76,151 @@ func TestNewNetworkServiceEndpointRegistryClient(t *testing.T) {
 	defer testClient.Unlock()
 	require.Equal(t, 1, testClient.requestCount)
 }
+
+type errorsNSEClient struct {
+	testNSEClient
+	errs     []error
+	requests []*registry.NetworkServiceEndpoint
+}
+
+func (t *errorsNSEClient) Register(ctx context.Context, in *registry.NetworkServiceEndpoint, opts ...grpc.CallOption) (*registry.NetworkServiceEndpoint, error) {
+	t.Lock()
+	defer t.Unlock()
+	t.requests = append(t.requests, in)
+	if len(t.errs) > 0 {
+		err := t.errs[0]
+		t.errs = t.errs[1:]
+		if err != nil {
+			return nil, err
+		}
+	}
+	out := *in
+	out.Url = "changed-by-registry"
+	return &out, nil
+}
+
+func TestNewNetworkServiceEndpointRegistryClient_NotFound(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	testClient := &errorsNSEClient{
+		errs: []error{nil, status.Error(codes.NotFound, "nse-1 is not found")},
+	}
+	statusCh := make(chan refresh.Status, 10)
+	refreshClient := refresh.NewNetworkServiceEndpointRegistryClient(testClient,
+		refresh.WithRetryPeriod(time.Hour),
+		refresh.WithStatusCallback(func(_ *registry.NetworkServiceEndpoint, s refresh.Status, _ error) {
+			statusCh <- s
+		}),
+	)
+	expirationTime := time.Now().Add(time.Millisecond * 150)
+	_, err := next.NewNetworkServiceEndpointRegistryClient(refreshClient, testClient).Register(context.Background(), &registry.NetworkServiceEndpoint{
+		Name: "nse-1",
+		Url:  "original",
+		ExpirationTime: &timestamp.Timestamp{
+			Seconds: expirationTime.Unix(),
+			Nanos:   int32(expirationTime.Nanosecond()),
+		},
+	})
+	require.Nil(t, err)
+	require.Equal(t, refresh.StatusLost, <-statusCh)
+	require.Equal(t, refresh.StatusRegistered, <-statusCh)
+	_, err = refreshClient.Unregister(context.Background(), &registry.NetworkServiceEndpoint{Name: "nse-1"})
+	require.Nil(t, err)
+
+	testClient.Lock()
+	defer testClient.Unlock()
+	require.Len(t, testClient.requests, 3)
+	require.Equal(t, "changed-by-registry", testClient.requests[1].Url)
+	require.Equal(t, "original", testClient.requests[2].Url)
+}
+
+func TestNewNetworkServiceEndpointRegistryClient_ExponentialBackoff(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	testClient := &errorsNSEClient{}
+	for i := 0; i < 100; i++ {
+		testClient.errs = append(testClient.errs, errors.New("registry is unavailable"))
+	}
+	statusCh := make(chan refresh.Status, 10)
+	refreshClient := refresh.NewNetworkServiceEndpointRegistryClient(testClient,
+		refresh.WithRetryPeriod(time.Millisecond*10),
+		refresh.WithExponentialBackoff(time.Millisecond*80),
+		refresh.WithStatusCallback(func(_ *registry.NetworkServiceEndpoint, s refresh.Status, _ error) {
+			statusCh <- s
+		}),
+	)
+	expirationTime := time.Now().Add(time.Millisecond * 30)
+	_, err := refreshClient.Register(context.Background(), &registry.NetworkServiceEndpoint{
+		Name: "nse-1",
+		ExpirationTime: &timestamp.Timestamp{
+			Seconds: expirationTime.Unix(),
+			Nanos:   int32(expirationTime.Nanosecond()),
+		},
+	})
+	require.Nil(t, err)
+	require.Equal(t, refresh.StatusRetrying, <-statusCh)
+	// 20ms before the first refresh, then 10 + 20 + 40 + 80 + 80 ms of retries
+	<-time.After(time.Millisecond * 300)
+	_, err = refreshClient.Unregister(context.Background(), &registry.NetworkServiceEndpoint{Name: "nse-1"})
+	require.Nil(t, err)
+
+	testClient.Lock()
+	defer testClient.Unlock()
+	require.GreaterOrEqual(t, len(testClient.requests), 4)
+	require.LessOrEqual(t, len(testClient.requests), 7)
+	require.Empty(t, statusCh)
+}
+
+type namingNSEClient struct {
+	errorsNSEClient
+	names []string
+}
+
+func (t *namingNSEClient) Register(ctx context.Context, in *registry.NetworkServiceEndpoint, opts ...grpc.CallOption) (*registry.NetworkServiceEndpoint, error) {
+	resp, err := t.errorsNSEClient.Register(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+	t.Lock()
+	defer t.Unlock()
+	if len(t.names) > 0 {
+		resp.Name = t.names[0]
+		t.names = t.names[1:]
+	}
+	return resp, nil
+}
+
+func TestNewNetworkServiceEndpointRegistryClient_NotFoundName(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	testClient := &namingNSEClient{
+		errorsNSEClient: errorsNSEClient{
+			errs: []error{nil, status.Error(codes.NotFound, "nse-1 is not found")},
+		},
+		names: []string{"nse-1", "nse-2"},
+	}
+	statusCh := make(chan refresh.Status, 10)
+	refreshClient := refresh.NewNetworkServiceEndpointRegistryClient(testClient,
+		refresh.WithRetryPeriod(time.Hour),
+		refresh.WithStatusCallback(func(_ *registry.NetworkServiceEndpoint, s refresh.Status, _ error) {
+			statusCh <- s
+		}),
+	)
+	expirationTime := time.Now().Add(time.Millisecond * 150)
+	resp, err := next.NewNetworkServiceEndpointRegistryClient(refreshClient, testClient).Register(context.Background(), &registry.NetworkServiceEndpoint{
+		ExpirationTime: &timestamp.Timestamp{
+			Seconds: expirationTime.Unix(),
+			Nanos:   int32(expirationTime.Nanosecond()),
+		},
+	})
+	require.Nil(t, err)
+	require.Equal(t, "nse-1", resp.Name)
+	require.Equal(t, refresh.StatusLost, <-statusCh)
+	require.Equal(t, refresh.StatusRegistered, <-statusCh)
+	// The refresh has moved to the name given by the registry on the re-registration, so it is stopped by it
+	_, err = refreshClient.Unregister(context.Background(), &registry.NetworkServiceEndpoint{Name: "nse-2"})
+	require.Nil(t, err)
+
+	testClient.Lock()
+	defer testClient.Unlock()
+	require.Len(t, testClient.requests, 3)
+	require.Equal(t, "nse-1", testClient.requests[1].Name)
+	require.Equal(t, "nse-1", testClient.requests[2].Name)
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package refresh
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+)
+
+// MetadataKey is the gRPC metadata key marking the Register requests sent by the refresh client to refresh an already
+// registered NSE
+const MetadataKey = "nsm-refresh"
+
+type refreshNSEServer struct{}
+
+func (s *refreshNSEServer) Register(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	if isRefresh(ctx) {
+		found, err := s.exists(ctx, nse.Name)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			return nil, status.Errorf(codes.NotFound, "network service endpoint %v is not registered", nse.Name)
+		}
+	}
+	return next.NetworkServiceEndpointRegistryServer(ctx).Register(ctx, nse)
+}
+
+func (s *refreshNSEServer) Find(query *registry.NetworkServiceEndpointQuery, server registry.NetworkServiceEndpointRegistry_FindServer) error {
+	return next.NetworkServiceEndpointRegistryServer(server.Context()).Find(query, server)
+}
+
+func (s *refreshNSEServer) Unregister(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*empty.Empty, error) {
+	return next.NetworkServiceEndpointRegistryServer(ctx).Unregister(ctx, nse)
+}
+
+// exists looks the NSE with the name up in the rest of the chain
+func (s *refreshNSEServer) exists(ctx context.Context, name string) (bool, error) {
+	if name == "" {
+		return false, nil
+	}
+	server := &existsFindServer{ctx: ctx, name: name}
+	err := next.NetworkServiceEndpointRegistryServer(ctx).Find(&registry.NetworkServiceEndpointQuery{
+		NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{Name: name},
+	}, server)
+	if err != nil {
+		return false, err
+	}
+	return server.found, nil
+}
+
+type existsFindServer struct {
+	grpc.ServerStream
+	ctx   context.Context
+	name  string
+	found bool
+}
+
+func (s *existsFindServer) Send(nse *registry.NetworkServiceEndpoint) error {
+	// The query matches the names containing the name, so only the exact match is counted
+	if nse.Name == s.name {
+		s.found = true
+	}
+	return nil
+}
+
+func (s *existsFindServer) Context() context.Context {
+	return s.ctx
+}
+
+func isRefresh(ctx context.Context) bool {
+	md, ok := metadata.FromIncomingContext(ctx)
+	return ok && len(md.Get(MetadataKey)) > 0
+}
+
+// NewNetworkServiceEndpointRegistryServer creates new NetworkServiceEndpointRegistryServer answering NotFound to the
+// refreshes of the NSEs unknown to the rest of the chain, e.g. expired or lost on the registry restart. The refreshes
+// are the Register requests marked with MetadataKey by the refresh client, so the client registers such NSEs again.
+// It should be placed before the storage of the registry, e.g. memory.
+func NewNetworkServiceEndpointRegistryServer() registry.NetworkServiceEndpointRegistryServer {
+	return &refreshNSEServer{}
+}
+
+var _ registry.NetworkServiceEndpointRegistryServer = &refreshNSEServer{}
+var _ registry.NetworkServiceEndpointRegistry_FindServer = &existsFindServer{}
@@ -0,0 +1,147 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package refresh_test
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/common/expire"
+	"github.com/networkservicemesh/sdk/pkg/registry/common/refresh"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/adapters"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+	"github.com/networkservicemesh/sdk/pkg/registry/memory"
+)
+
+// pausingNSEClient delays the first refresh past the NSE expiration time
+type pausingNSEClient struct {
+	sync.Mutex
+	client   registry.NetworkServiceEndpointRegistryClient
+	pause    time.Duration
+	requests int
+}
+
+func (c *pausingNSEClient) Register(ctx context.Context, in *registry.NetworkServiceEndpoint, opts ...grpc.CallOption) (*registry.NetworkServiceEndpoint, error) {
+	c.Lock()
+	c.requests++
+	first := c.requests == 2
+	c.Unlock()
+	if first {
+		<-time.After(c.pause)
+	}
+	return c.client.Register(ctx, in, opts...)
+}
+
+func (c *pausingNSEClient) Find(ctx context.Context, in *registry.NetworkServiceEndpointQuery, opts ...grpc.CallOption) (registry.NetworkServiceEndpointRegistry_FindClient, error) {
+	return c.client.Find(ctx, in, opts...)
+}
+
+func (c *pausingNSEClient) Unregister(ctx context.Context, in *registry.NetworkServiceEndpoint, opts ...grpc.CallOption) (*empty.Empty, error) {
+	return c.client.Unregister(ctx, in, opts...)
+}
+
+func TestNewNetworkServiceEndpointRegistryServer_ExpiredRefresh(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	registryServer := expire.NewNetworkServiceEndpointRegistryServer(ctx, next.NewNetworkServiceEndpointRegistryServer(
+		refresh.NewNetworkServiceEndpointRegistryServer(),
+		memory.NewNetworkServiceEndpointRegistryServer(),
+	))
+	server := grpc.NewServer()
+	registry.RegisterNetworkServiceEndpointRegistryServer(server, registryServer)
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() { _ = server.Serve(listener) }()
+	defer server.Stop()
+
+	cc, err := grpc.DialContext(ctx, listener.Addr().String(), grpc.WithInsecure(), grpc.WithBlock())
+	require.NoError(t, err)
+	defer func() { _ = cc.Close() }()
+
+	pausingClient := &pausingNSEClient{
+		client: registry.NewNetworkServiceEndpointRegistryClient(cc),
+		pause:  time.Millisecond * 300,
+	}
+	statusCh := make(chan refresh.Status, 10)
+	errCh := make(chan error, 10)
+	refreshClient := refresh.NewNetworkServiceEndpointRegistryClient(pausingClient,
+		refresh.WithRetryPeriod(time.Hour),
+		refresh.WithStatusCallback(func(_ *registry.NetworkServiceEndpoint, s refresh.Status, err error) {
+			statusCh <- s
+			errCh <- err
+		}),
+	)
+	c := next.NewNetworkServiceEndpointRegistryClient(refreshClient, pausingClient)
+	expirationTime := time.Now().Add(time.Millisecond * 200)
+	_, err = c.Register(ctx, &registry.NetworkServiceEndpoint{
+		Name: "nse-1",
+		ExpirationTime: &timestamp.Timestamp{
+			Seconds: expirationTime.Unix(),
+			Nanos:   int32(expirationTime.Nanosecond()),
+		},
+	})
+	require.NoError(t, err)
+
+	// The paused refresh comes after the registry has expired the NSE
+	require.Equal(t, refresh.StatusLost, <-statusCh)
+	require.Equal(t, codes.NotFound, status.Code(<-errCh))
+	require.Equal(t, refresh.StatusRegistered, <-statusCh)
+	<-errCh
+
+	stream, err := adapters.NetworkServiceEndpointServerToClient(registryServer).Find(ctx, &registry.NetworkServiceEndpointQuery{
+		NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{Name: "nse-1"},
+	})
+	require.NoError(t, err)
+	require.Len(t, registry.ReadNetworkServiceEndpointList(stream), 1)
+
+	_, err = c.Unregister(ctx, &registry.NetworkServiceEndpoint{Name: "nse-1"})
+	require.NoError(t, err)
+}
+
+func TestNewNetworkServiceEndpointRegistryServer_UnknownName(t *testing.T) {
+	s := next.NewNetworkServiceEndpointRegistryServer(
+		refresh.NewNetworkServiceEndpointRegistryServer(),
+		memory.NewNetworkServiceEndpointRegistryServer(),
+	)
+	refreshCtx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(refresh.MetadataKey, "true"))
+
+	_, err := s.Register(refreshCtx, &registry.NetworkServiceEndpoint{Name: "nse-1"})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	// Registrations are not checked
+	_, err = s.Register(context.Background(), &registry.NetworkServiceEndpoint{Name: "nse-10"})
+	require.NoError(t, err)
+	_, err = s.Register(refreshCtx, &registry.NetworkServiceEndpoint{Name: "nse-1"})
+	require.Equal(t, codes.NotFound, status.Code(err))
+	_, err = s.Register(refreshCtx, &registry.NetworkServiceEndpoint{Name: "nse-10"})
+	require.NoError(t, err)
+}
@@ -20,6 +20,8 @@ import "time"
 
 type configurable interface {
 	setRetryPeriod(time.Duration)
+	setMaxRetryPeriod(time.Duration)
+	setStatusCallback(StatusCallback)
 }
 
 // Option is expire registry configuration option
@@ -39,3 +41,18 @@ func WithRetryPeriod(duration time.Duration) Option {
 		c.setRetryPeriod(duration)
 	})
 }
+
+// WithExponentialBackoff makes the retry period double after each failed refresh up to maxDuration.
+// The retry period starts from the value set by WithRetryPeriod and is reset after a successful refresh.
+func WithExponentialBackoff(maxDuration time.Duration) Option {
+	return applierFunc(func(c configurable) {
+		c.setMaxRetryPeriod(maxDuration)
+	})
+}
+
+// WithStatusCallback sets a callback to be notified about registration health changes of refreshed NSEs
+func WithStatusCallback(callback StatusCallback) Option {
+	return applierFunc(func(c configurable) {
+		c.setStatusCallback(callback)
+	})
+}
@@ -0,0 +1,49 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package refresh
+
+import "github.com/networkservicemesh/api/pkg/api/registry"
+
+// Status is a registration health status of refreshed NetworkServiceEndpoint
+type Status int
+
+const (
+	// StatusRegistered means that the last refresh has succeeded
+	StatusRegistered Status = iota
+	// StatusRetrying means that the last refresh has failed and it is going to be retried
+	StatusRetrying
+	// StatusLost means that the registry doesn't know the NetworkServiceEndpoint anymore and it is going to be fully re-registered
+	StatusLost
+)
+
+func (s Status) String() string {
+	switch s {
+	case StatusRegistered:
+		return "Registered"
+	case StatusRetrying:
+		return "Retrying"
+	case StatusLost:
+		return "Lost"
+	}
+	return "Unknown"
+}
+
+// StatusCallback is called every time when registration health status of refreshed NetworkServiceEndpoint changes.
+// err is the error returned by the registry, it is nil for StatusRegistered.
+// Each refreshed NetworkServiceEndpoint has its own refresh goroutine, so the callback may be called concurrently for
+// different NSEs and must be safe for concurrent use.
+type StatusCallback func(nse *registry.NetworkServiceEndpoint, status Status, err error)
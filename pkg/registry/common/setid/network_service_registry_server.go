@@ -19,19 +19,59 @@ package setid
 import (
 	"context"
 	"strings"
+	"sync"
 
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/google/uuid"
 	"github.com/networkservicemesh/api/pkg/api/registry"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/ownership"
+	"github.com/networkservicemesh/sdk/pkg/tools/peerid"
 )
 
-type setIDNetworkServiceEndpointRegistryServer struct{}
+type setIDNetworkServiceEndpointRegistryServer struct {
+	claims           *ownership.Table
+	mutex            sync.Mutex
+	renameOnConflict bool
+	identity         IdentityFunc
+}
 
 func (n *setIDNetworkServiceEndpointRegistryServer) Register(ctx context.Context, request *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	identity := n.identity(ctx)
 	request.Name = nameOf(request)
-	return next.NetworkServiceEndpointRegistryServer(ctx).Register(ctx, request)
+
+	n.mutex.Lock()
+	if c := n.claims.Get(request.Name); c != nil && c.Identity != identity {
+		if !n.renameOnConflict {
+			n.mutex.Unlock()
+			return nil, status.Errorf(codes.AlreadyExists, "network service endpoint %v is already registered by another identity", request.Name)
+		}
+		request.Name = strings.Join([]string{request.Name, uuid.New().String()}, "-")
+	}
+	// Callers without identity can't be told from each other, so they don't claim names
+	if identity == "" {
+		n.mutex.Unlock()
+		return next.NetworkServiceEndpointRegistryServer(ctx).Register(ctx, request)
+	}
+	prev := n.claims.Get(request.Name)
+	n.claims.Set(request.Name, ownership.NewOwner(identity, nil))
+	n.mutex.Unlock()
+
+	resp, err := next.NetworkServiceEndpointRegistryServer(ctx).Register(ctx, request)
+	if err != nil {
+		n.mutex.Lock()
+		n.claims.Set(request.Name, prev)
+		n.mutex.Unlock()
+		return nil, err
+	}
+
+	n.mutex.Lock()
+	n.claims.Set(resp.Name, ownership.NewOwner(identity, resp.ExpirationTime))
+	n.mutex.Unlock()
+	return resp, nil
 }
 
 type setIDNetworkServiceEndpointRegistryFindServer struct {
@@ -48,12 +88,37 @@ func (n *setIDNetworkServiceEndpointRegistryServer) Find(query *registry.Network
 }
 
 func (n *setIDNetworkServiceEndpointRegistryServer) Unregister(ctx context.Context, request *registry.NetworkServiceEndpoint) (*empty.Empty, error) {
-	return next.NetworkServiceEndpointRegistryServer(ctx).Unregister(ctx, request)
+	identity := n.identity(ctx)
+	n.mutex.Lock()
+	c := n.claims.Get(request.Name)
+	n.mutex.Unlock()
+	if c != nil && c.Identity != identity {
+		return nil, status.Errorf(codes.PermissionDenied, "network service endpoint %v is registered by another identity", request.Name)
+	}
+	resp, err := next.NetworkServiceEndpointRegistryServer(ctx).Unregister(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	n.mutex.Lock()
+	n.claims.Set(request.Name, nil)
+	n.mutex.Unlock()
+	return resp, nil
 }
 
-// NewNetworkServiceEndpointRegistryServer creates new instance of NetworkServiceRegistryServer which set the unique name for the endpoint on registration
-func NewNetworkServiceEndpointRegistryServer() registry.NetworkServiceEndpointRegistryServer {
-	return &setIDNetworkServiceEndpointRegistryServer{}
+// NewNetworkServiceEndpointRegistryServer creates new instance of NetworkServiceRegistryServer which set the unique name for the endpoint on registration.
+// A name is claimed by the identity registering it until the endpoint is unregistered or expires. Registration of
+// the claimed name by another identity fails with AlreadyExists unless WithUniqueSuffixOnConflict is set, its
+// unregistration by another identity fails with PermissionDenied. Callers without identity (e.g. with no TLS peer
+// certificate and no token for WithTokenIdentity) don't claim names, so the conflicts between them are not detected.
+func NewNetworkServiceEndpointRegistryServer(options ...Option) registry.NetworkServiceEndpointRegistryServer {
+	s := &setIDNetworkServiceEndpointRegistryServer{
+		claims:   ownership.NewTable(),
+		identity: peerid.Identity,
+	}
+	for _, o := range options {
+		o.apply(s)
+	}
+	return s
 }
 
 func nameOf(endpoint *registry.NetworkServiceEndpoint) string {
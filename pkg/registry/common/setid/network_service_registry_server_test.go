@@ -19,6 +19,13 @@ package setid_test
 import (
 	"context"
 	"testing"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 
 	"github.com/networkservicemesh/sdk/pkg/registry/common/setid"
 
@@ -26,6 +33,16 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+type identityKey struct{}
+
+func withIdentity(identity string) context.Context {
+	return context.WithValue(context.Background(), identityKey{}, identity)
+}
+
+func identity(ctx context.Context) string {
+	return ctx.Value(identityKey{}).(string)
+}
+
 func TestSetIDNetworkServiceRegistryServer_RegisterNSE(t *testing.T) {
 	s := setid.NewNetworkServiceEndpointRegistryServer()
 	nse := &registry.NetworkServiceEndpoint{
@@ -35,3 +52,104 @@ func TestSetIDNetworkServiceRegistryServer_RegisterNSE(t *testing.T) {
 	require.Nil(t, err)
 	require.NotEmpty(t, resp.Name)
 }
+
+func TestSetIDNetworkServiceRegistryServer_Conflict(t *testing.T) {
+	s := setid.NewNetworkServiceEndpointRegistryServer(setid.WithIdentityFunc(identity))
+
+	_, err := s.Register(withIdentity("a"), &registry.NetworkServiceEndpoint{Name: "nse-1"})
+	require.Nil(t, err)
+	_, err = s.Register(withIdentity("a"), &registry.NetworkServiceEndpoint{Name: "nse-1"})
+	require.Nil(t, err)
+
+	_, err = s.Register(withIdentity("b"), &registry.NetworkServiceEndpoint{Name: "nse-1"})
+	require.NotNil(t, err)
+	require.Equal(t, codes.AlreadyExists, status.Code(err))
+
+	_, err = s.Unregister(withIdentity("a"), &registry.NetworkServiceEndpoint{Name: "nse-1"})
+	require.Nil(t, err)
+	_, err = s.Register(withIdentity("b"), &registry.NetworkServiceEndpoint{Name: "nse-1"})
+	require.Nil(t, err)
+}
+
+func TestSetIDNetworkServiceRegistryServer_ConflictExpired(t *testing.T) {
+	s := setid.NewNetworkServiceEndpointRegistryServer(setid.WithIdentityFunc(identity))
+
+	expirationTime := time.Now().Add(time.Millisecond * 50)
+	_, err := s.Register(withIdentity("a"), &registry.NetworkServiceEndpoint{
+		Name: "nse-1",
+		ExpirationTime: &timestamp.Timestamp{
+			Seconds: expirationTime.Unix(),
+			Nanos:   int32(expirationTime.Nanosecond()),
+		},
+	})
+	require.Nil(t, err)
+	_, err = s.Register(withIdentity("b"), &registry.NetworkServiceEndpoint{Name: "nse-1"})
+	require.Equal(t, codes.AlreadyExists, status.Code(err))
+
+	<-time.After(time.Millisecond * 100)
+	_, err = s.Register(withIdentity("b"), &registry.NetworkServiceEndpoint{Name: "nse-1"})
+	require.Nil(t, err)
+}
+
+func TestSetIDNetworkServiceRegistryServer_UniqueSuffixOnConflict(t *testing.T) {
+	s := setid.NewNetworkServiceEndpointRegistryServer(setid.WithIdentityFunc(identity), setid.WithUniqueSuffixOnConflict())
+
+	resp, err := s.Register(withIdentity("a"), &registry.NetworkServiceEndpoint{Name: "nse-1"})
+	require.Nil(t, err)
+	require.Equal(t, "nse-1", resp.Name)
+
+	resp, err = s.Register(withIdentity("b"), &registry.NetworkServiceEndpoint{Name: "nse-1"})
+	require.Nil(t, err)
+	require.NotEqual(t, "nse-1", resp.Name)
+	require.Contains(t, resp.Name, "nse-1-")
+
+	renamed := resp.Name
+	resp, err = s.Register(withIdentity("b"), &registry.NetworkServiceEndpoint{Name: renamed})
+	require.Nil(t, err)
+	require.Equal(t, renamed, resp.Name)
+}
+
+func TestSetIDNetworkServiceRegistryServer_UnregisterConflict(t *testing.T) {
+	s := setid.NewNetworkServiceEndpointRegistryServer(setid.WithIdentityFunc(identity))
+
+	_, err := s.Register(withIdentity("a"), &registry.NetworkServiceEndpoint{Name: "nse-1"})
+	require.Nil(t, err)
+
+	_, err = s.Unregister(withIdentity("b"), &registry.NetworkServiceEndpoint{Name: "nse-1"})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = s.Unregister(withIdentity(""), &registry.NetworkServiceEndpoint{Name: "nse-1"})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// Anonymous callers don't claim names and can't take the claimed ones
+	_, err = s.Register(withIdentity(""), &registry.NetworkServiceEndpoint{Name: "nse-1"})
+	require.Equal(t, codes.AlreadyExists, status.Code(err))
+	_, err = s.Register(withIdentity(""), &registry.NetworkServiceEndpoint{Name: "nse-2"})
+	require.Nil(t, err)
+	_, err = s.Register(withIdentity("b"), &registry.NetworkServiceEndpoint{Name: "nse-2"})
+	require.Nil(t, err)
+
+	_, err = s.Unregister(withIdentity("a"), &registry.NetworkServiceEndpoint{Name: "nse-1"})
+	require.Nil(t, err)
+}
+
+func TestSetIDNetworkServiceRegistryServer_TokenIdentity(t *testing.T) {
+	key := []byte("secret")
+	s := setid.NewNetworkServiceEndpointRegistryServer(setid.WithTokenIdentity(func(*jwt.Token) (interface{}, error) {
+		return key, nil
+	}))
+	withToken := func(subject string, key []byte) context.Context {
+		tok, err := jwt.NewWithClaims(jwt.SigningMethodHS256, &jwt.StandardClaims{Subject: subject}).SignedString(key)
+		require.Nil(t, err)
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs(setid.AuthorizationKey, "Bearer "+tok))
+	}
+
+	_, err := s.Register(withToken("spiffe://test.com/a", key), &registry.NetworkServiceEndpoint{Name: "nse-1"})
+	require.Nil(t, err)
+	_, err = s.Register(withToken("spiffe://test.com/b", key), &registry.NetworkServiceEndpoint{Name: "nse-1"})
+	require.Equal(t, codes.AlreadyExists, status.Code(err))
+	// The token signed by another key gives no identity
+	_, err = s.Register(withToken("spiffe://test.com/a", []byte("forged")), &registry.NetworkServiceEndpoint{Name: "nse-1"})
+	require.Equal(t, codes.AlreadyExists, status.Code(err))
+	_, err = s.Register(withToken("spiffe://test.com/a", key), &registry.NetworkServiceEndpoint{Name: "nse-1"})
+	require.Nil(t, err)
+}
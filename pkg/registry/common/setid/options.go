@@ -0,0 +1,77 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package setid
+
+import (
+	"context"
+	"strings"
+
+	"github.com/dgrijalva/jwt-go"
+	"google.golang.org/grpc/metadata"
+)
+
+// AuthorizationKey is the gRPC metadata key of the bearer token read by WithTokenIdentity
+const AuthorizationKey = "authorization"
+
+// IdentityFunc returns identity of the caller registering NetworkServiceEndpoint
+type IdentityFunc func(ctx context.Context) string
+
+// Option is setid registry configuration option
+type Option interface {
+	apply(*setIDNetworkServiceEndpointRegistryServer)
+}
+
+type applierFunc func(*setIDNetworkServiceEndpointRegistryServer)
+
+func (f applierFunc) apply(s *setIDNetworkServiceEndpointRegistryServer) {
+	f(s)
+}
+
+// WithUniqueSuffixOnConflict makes conflicting registration get a unique suffix added to the name instead of
+// failing with AlreadyExists
+func WithUniqueSuffixOnConflict() Option {
+	return applierFunc(func(s *setIDNetworkServiceEndpointRegistryServer) {
+		s.renameOnConflict = true
+	})
+}
+
+// WithIdentityFunc sets a function getting identity of the caller, by default it is SPIFFE ID from the peer certificate
+func WithIdentityFunc(identity IdentityFunc) Option {
+	return applierFunc(func(s *setIDNetworkServiceEndpointRegistryServer) {
+		s.identity = identity
+	})
+}
+
+// WithTokenIdentity sets identity of the caller to the subject of the bearer JWT from the AuthorizationKey gRPC
+// metadata of the request. The token is verified with keyFunc, callers without a valid token have no identity.
+func WithTokenIdentity(keyFunc jwt.Keyfunc) Option {
+	return WithIdentityFunc(func(ctx context.Context) string {
+		md, ok := metadata.FromIncomingContext(ctx)
+		if !ok {
+			return ""
+		}
+		values := md.Get(AuthorizationKey)
+		if len(values) == 0 {
+			return ""
+		}
+		claims := new(jwt.StandardClaims)
+		if _, err := jwt.ParseWithClaims(strings.TrimPrefix(values[0], "Bearer "), claims, keyFunc); err != nil {
+			return ""
+		}
+		return claims.Subject
+	})
+}
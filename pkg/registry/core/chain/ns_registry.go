@@ -21,12 +21,18 @@ package chain
 import (
 	"github.com/networkservicemesh/api/pkg/api/registry"
 
+	"github.com/networkservicemesh/sdk/pkg/registry/core/metrics"
 	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+	toolsmetrics "github.com/networkservicemesh/sdk/pkg/tools/metrics"
 )
 
-// NewNetworkServiceRegistryServer - creates a chain of servers
+// NewNetworkServiceRegistryServer - creates a chain of servers, with metrics if toolsmetrics.IsEnabled()
 func NewNetworkServiceRegistryServer(servers ...registry.NetworkServiceRegistryServer) registry.NetworkServiceRegistryServer {
+	metricsEnabled := toolsmetrics.IsEnabled()
 	return next.NewWrappedNetworkServiceRegistryServer(func(server registry.NetworkServiceRegistryServer) registry.NetworkServiceRegistryServer {
+		if metricsEnabled {
+			return metrics.NewNetworkServiceRegistryServer(server)
+		}
 		return server
 	}, servers...)
 }
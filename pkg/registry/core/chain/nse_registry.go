@@ -20,12 +20,18 @@ package chain
 import (
 	"github.com/networkservicemesh/api/pkg/api/registry"
 
+	"github.com/networkservicemesh/sdk/pkg/registry/core/metrics"
 	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+	toolsmetrics "github.com/networkservicemesh/sdk/pkg/tools/metrics"
 )
 
-// NewNetworkServiceEndpointRegistryServer - creates a chain of servers
+// NewNetworkServiceEndpointRegistryServer - creates a chain of servers, with metrics if toolsmetrics.IsEnabled()
 func NewNetworkServiceEndpointRegistryServer(servers ...registry.NetworkServiceEndpointRegistryServer) registry.NetworkServiceEndpointRegistryServer {
+	metricsEnabled := toolsmetrics.IsEnabled()
 	return next.NewWrappedNetworkServiceEndpointRegistryServer(func(server registry.NetworkServiceEndpointRegistryServer) registry.NetworkServiceEndpointRegistryServer {
+		if metricsEnabled {
+			return metrics.NewNetworkServiceEndpointRegistryServer(server)
+		}
 		return server
 	}, servers...)
 }
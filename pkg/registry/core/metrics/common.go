@@ -0,0 +1,88 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package metrics provides chain elements collecting Prometheus-style metrics of the wrapped registry servers: calls
+// counters and latency histograms labeled by the element, method and status code
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc/status"
+
+	toolsmetrics "github.com/networkservicemesh/sdk/pkg/tools/metrics"
+	"github.com/networkservicemesh/sdk/pkg/tools/typeutils"
+)
+
+const (
+	nsRegistry  = "ns"
+	nseRegistry = "nse"
+)
+
+type options struct {
+	registry *toolsmetrics.Registry
+	name     string
+}
+
+// Option is an option pattern for NewNetworkServiceRegistryServer, NewNetworkServiceEndpointRegistryServer
+type Option func(o *options)
+
+// WithRegistry sets registry to collect the metrics to, toolsmetrics.DefaultRegistry is used by default
+func WithRegistry(registry *toolsmetrics.Registry) Option {
+	return func(o *options) {
+		o.registry = registry
+	}
+}
+
+// WithName sets the element label of the metrics, the type name of the wrapped element is used by default
+func WithName(name string) Option {
+	return func(o *options) {
+		o.name = name
+	}
+}
+
+type meter struct {
+	element  string
+	registry string
+
+	calls    *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+}
+
+func newMeter(element interface{}, registry string, opts []Option) *meter {
+	o := &options{
+		registry: toolsmetrics.DefaultRegistry,
+		name:     typeutils.GetTypeName(element),
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return &meter{
+		element:  o.name,
+		registry: registry,
+		calls: o.registry.Counter("nsm_registry_calls_total", "Total number of the registry calls",
+			"element", "registry", "method", "code"),
+		duration: o.registry.Histogram("nsm_registry_duration_seconds", "Duration of the registry calls in seconds",
+			toolsmetrics.DefaultBuckets, "element", "registry", "method", "code"),
+	}
+}
+
+func (m *meter) observe(start time.Time, method string, err error) {
+	code := status.Code(err).String()
+	m.calls.WithLabelValues(m.element, m.registry, method, code).Inc()
+	m.duration.WithLabelValues(m.element, m.registry, method, code).Observe(time.Since(start).Seconds())
+}
@@ -0,0 +1,60 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+)
+
+type metricsNetworkServiceRegistryServer struct {
+	measured registry.NetworkServiceRegistryServer
+	meter    *meter
+}
+
+// NewNetworkServiceRegistryServer - wraps metrics collection around the supplied measured
+func NewNetworkServiceRegistryServer(measured registry.NetworkServiceRegistryServer, options ...Option) registry.NetworkServiceRegistryServer {
+	return &metricsNetworkServiceRegistryServer{
+		measured: measured,
+		meter:    newMeter(measured, nsRegistry, options),
+	}
+}
+
+func (s *metricsNetworkServiceRegistryServer) Register(ctx context.Context, in *registry.NetworkService) (*registry.NetworkService, error) {
+	start := time.Now()
+	rv, err := s.measured.Register(ctx, in)
+	s.meter.observe(start, "Register", err)
+	return rv, err
+}
+
+func (s *metricsNetworkServiceRegistryServer) Find(query *registry.NetworkServiceQuery, server registry.NetworkServiceRegistry_FindServer) error {
+	start := time.Now()
+	err := s.measured.Find(query, server)
+	s.meter.observe(start, "Find", err)
+	return err
+}
+
+func (s *metricsNetworkServiceRegistryServer) Unregister(ctx context.Context, in *registry.NetworkService) (*empty.Empty, error) {
+	start := time.Now()
+	rv, err := s.measured.Unregister(ctx, in)
+	s.meter.observe(start, "Unregister", err)
+	return rv, err
+}
@@ -0,0 +1,60 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+)
+
+type metricsNetworkServiceEndpointRegistryServer struct {
+	measured registry.NetworkServiceEndpointRegistryServer
+	meter    *meter
+}
+
+// NewNetworkServiceEndpointRegistryServer - wraps metrics collection around the supplied measured
+func NewNetworkServiceEndpointRegistryServer(measured registry.NetworkServiceEndpointRegistryServer, options ...Option) registry.NetworkServiceEndpointRegistryServer {
+	return &metricsNetworkServiceEndpointRegistryServer{
+		measured: measured,
+		meter:    newMeter(measured, nseRegistry, options),
+	}
+}
+
+func (s *metricsNetworkServiceEndpointRegistryServer) Register(ctx context.Context, in *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	start := time.Now()
+	rv, err := s.measured.Register(ctx, in)
+	s.meter.observe(start, "Register", err)
+	return rv, err
+}
+
+func (s *metricsNetworkServiceEndpointRegistryServer) Find(query *registry.NetworkServiceEndpointQuery, server registry.NetworkServiceEndpointRegistry_FindServer) error {
+	start := time.Now()
+	err := s.measured.Find(query, server)
+	s.meter.observe(start, "Find", err)
+	return err
+}
+
+func (s *metricsNetworkServiceEndpointRegistryServer) Unregister(ctx context.Context, in *registry.NetworkServiceEndpoint) (*empty.Empty, error) {
+	start := time.Now()
+	rv, err := s.measured.Unregister(ctx, in)
+	s.meter.observe(start, "Unregister", err)
+	return rv, err
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/networkservicemesh/sdk/pkg/registry/core/streamcontext"
+	"github.com/networkservicemesh/sdk/pkg/tools/nsmerrors"
 	"github.com/networkservicemesh/sdk/pkg/tools/spanhelper"
 	"github.com/networkservicemesh/sdk/pkg/tools/typeutils"
 
@@ -49,14 +50,14 @@ func (t *traceNetworkServiceRegistryFindClient) Recv() (*registry.NetworkService
 	rv, err := s.Recv()
 	if err != nil {
 		if _, ok := err.(stackTracer); !ok {
-			err = errors.Wrapf(err, "Error returned from %s", operation)
+			err = nsmerrors.KeepStatus(errors.Wrapf(err, "Error returned from %s", operation))
 			span.LogErrorf("%+v", err)
 			return nil, err
 		}
 		span.LogErrorf("%v", err)
-		return nil, err
+		return nil, nsmerrors.KeepStatus(err)
 	}
-	span.LogObject("response", rv)
+	logObject(span, "response", rv)
 	return rv, err
 }
 
@@ -67,19 +68,19 @@ func (t *traceNetworkServiceRegistryClient) Register(ctx context.Context, in *re
 
 	ctx = withLog(span.Context(), span.Logger())
 
-	span.LogObject("request", in)
+	logObject(span, "request", in)
 
 	rv, err := t.traced.Register(ctx, in, opts...)
 	if err != nil {
 		if _, ok := err.(stackTracer); !ok {
-			err = errors.Wrapf(err, "Error returned from %s", operation)
+			err = nsmerrors.KeepStatus(errors.Wrapf(err, "Error returned from %s", operation))
 			span.LogErrorf("%+v", err)
 			return nil, err
 		}
 		span.LogErrorf("%v", err)
-		return nil, err
+		return nil, nsmerrors.KeepStatus(err)
 	}
-	span.LogObject("response", rv)
+	logObject(span, "response", rv)
 	return rv, err
 }
 func (t *traceNetworkServiceRegistryClient) Find(ctx context.Context, in *registry.NetworkServiceQuery, opts ...grpc.CallOption) (registry.NetworkServiceRegistry_FindClient, error) {
@@ -89,20 +90,20 @@ func (t *traceNetworkServiceRegistryClient) Find(ctx context.Context, in *regist
 
 	ctx = withLog(span.Context(), span.Logger())
 
-	span.LogObject("find", in)
+	logObject(span, "find", in)
 
 	// Actually call the next
 	rv, err := t.traced.Find(ctx, in, opts...)
 	if err != nil {
 		if _, ok := err.(stackTracer); !ok {
-			err = errors.Wrapf(err, "Error returned from %s", operation)
+			err = nsmerrors.KeepStatus(errors.Wrapf(err, "Error returned from %s", operation))
 			span.LogErrorf("%+v", err)
 			return nil, err
 		}
 		span.LogErrorf("%v", err)
-		return nil, err
+		return nil, nsmerrors.KeepStatus(err)
 	}
-	span.LogObject("response", rv)
+	logObject(span, "response", rv)
 
 	return &traceNetworkServiceRegistryFindClient{NetworkServiceRegistry_FindClient: rv}, nil
 }
@@ -114,20 +115,20 @@ func (t *traceNetworkServiceRegistryClient) Unregister(ctx context.Context, in *
 
 	ctx = withLog(span.Context(), span.Logger())
 
-	span.LogObject("request", in)
+	logObject(span, "request", in)
 
 	// Actually call the next
 	rv, err := t.traced.Unregister(ctx, in, opts...)
 	if err != nil {
 		if _, ok := err.(stackTracer); !ok {
-			err = errors.Wrapf(err, "Error returned from %s", operation)
+			err = nsmerrors.KeepStatus(errors.Wrapf(err, "Error returned from %s", operation))
 			span.LogErrorf("%+v", err)
 			return nil, err
 		}
 		span.LogErrorf("%v", err)
-		return nil, err
+		return nil, nsmerrors.KeepStatus(err)
 	}
-	span.LogObject("response", rv)
+	logObject(span, "response", rv)
 	return rv, err
 }
 
@@ -147,19 +148,19 @@ func (t *traceNetworkServiceRegistryServer) Register(ctx context.Context, in *re
 
 	ctx = withLog(span.Context(), span.Logger())
 
-	span.LogObject("request", in)
+	logObject(span, "request", in)
 
 	rv, err := t.traced.Register(ctx, in)
 	if err != nil {
 		if _, ok := err.(stackTracer); !ok {
-			err = errors.Wrapf(err, "Error returned from %s", operation)
+			err = nsmerrors.KeepStatus(errors.Wrapf(err, "Error returned from %s", operation))
 			span.LogErrorf("%+v", err)
 			return nil, err
 		}
 		span.LogErrorf("%v", err)
-		return nil, err
+		return nil, nsmerrors.KeepStatus(err)
 	}
-	span.LogObject("response", rv)
+	logObject(span, "response", rv)
 	return rv, err
 }
 
@@ -172,18 +173,18 @@ func (t *traceNetworkServiceRegistryServer) Find(in *registry.NetworkServiceQuer
 	s = &traceNetworkServiceRegistryFindServer{
 		NetworkServiceRegistry_FindServer: streamcontext.NetworkServiceRegistryFindServer(ctx, s),
 	}
-	span.LogObject("find", in)
+	logObject(span, "find", in)
 
 	// Actually call the next
 	err := t.traced.Find(in, s)
 	if err != nil {
 		if _, ok := err.(stackTracer); !ok {
-			err = errors.Wrapf(err, "Error returned from %s", operation)
+			err = nsmerrors.KeepStatus(errors.Wrapf(err, "Error returned from %s", operation))
 			span.LogErrorf("%+v", err)
 			return err
 		}
 		span.LogErrorf("%v", err)
-		return err
+		return nsmerrors.KeepStatus(err)
 	}
 	return nil
 }
@@ -195,20 +196,20 @@ func (t *traceNetworkServiceRegistryServer) Unregister(ctx context.Context, in *
 
 	ctx = withLog(span.Context(), span.Logger())
 
-	span.LogObject("request", in)
+	logObject(span, "request", in)
 
 	// Actually call the next
 	rv, err := t.traced.Unregister(ctx, in)
 	if err != nil {
 		if _, ok := err.(stackTracer); !ok {
-			err = errors.Wrapf(err, "Error returned from %s", operation)
+			err = nsmerrors.KeepStatus(errors.Wrapf(err, "Error returned from %s", operation))
 			span.LogErrorf("%+v", err)
 			return nil, err
 		}
 		span.LogErrorf("%v", err)
-		return nil, err
+		return nil, nsmerrors.KeepStatus(err)
 	}
-	span.LogObject("response", rv)
+	logObject(span, "response", rv)
 	return rv, err
 }
 
@@ -225,18 +226,18 @@ func (t *traceNetworkServiceRegistryFindServer) Send(ns *registry.NetworkService
 	operation := typeutils.GetFuncName(t.NetworkServiceRegistry_FindServer, "Send")
 	span := spanhelper.FromContext(t.Context(), operation)
 	defer span.Finish()
-	span.LogObject("network service", ns)
+	logObject(span, "network service", ns)
 	ctx := withLog(span.Context(), span.Logger())
 	s := streamcontext.NetworkServiceRegistryFindServer(ctx, t)
 	err := s.Send(ns)
 	if err != nil {
 		if _, ok := err.(stackTracer); !ok {
-			err = errors.Wrapf(err, "Error returned from %s", operation)
+			err = nsmerrors.KeepStatus(errors.Wrapf(err, "Error returned from %s", operation))
 			span.LogErrorf("%+v", err)
 			return err
 		}
 		span.LogErrorf("%v", err)
-		return err
+		return nsmerrors.KeepStatus(err)
 	}
 	return err
 }
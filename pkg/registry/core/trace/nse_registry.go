@@ -22,6 +22,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/networkservicemesh/sdk/pkg/registry/core/streamcontext"
+	"github.com/networkservicemesh/sdk/pkg/tools/nsmerrors"
 	"github.com/networkservicemesh/sdk/pkg/tools/spanhelper"
 	"github.com/networkservicemesh/sdk/pkg/tools/typeutils"
 
@@ -49,14 +50,14 @@ func (t *traceNetworkServiceEndpointRegistryFindClient) Recv() (*registry.Networ
 	rv, err := s.Recv()
 	if err != nil {
 		if _, ok := err.(stackTracer); !ok {
-			err = errors.Wrapf(err, "Error returned from %s", operation)
+			err = nsmerrors.KeepStatus(errors.Wrapf(err, "Error returned from %s", operation))
 			span.LogErrorf("%+v", err)
 			return nil, err
 		}
 		span.LogErrorf("%v", err)
-		return nil, err
+		return nil, nsmerrors.KeepStatus(err)
 	}
-	span.LogObject("response", rv)
+	logObject(span, "response", rv)
 	return rv, err
 }
 
@@ -67,19 +68,19 @@ func (t *traceNetworkServiceEndpointRegistryClient) Register(ctx context.Context
 
 	ctx = withLog(span.Context(), span.Logger())
 
-	span.LogObject("request", in)
+	logObject(span, "request", in)
 
 	rv, err := t.traced.Register(ctx, in, opts...)
 	if err != nil {
 		if _, ok := err.(stackTracer); !ok {
-			err = errors.Wrapf(err, "Error returned from %s", operation)
+			err = nsmerrors.KeepStatus(errors.Wrapf(err, "Error returned from %s", operation))
 			span.LogErrorf("%+v", err)
 			return nil, err
 		}
 		span.LogErrorf("%v", err)
-		return nil, err
+		return nil, nsmerrors.KeepStatus(err)
 	}
-	span.LogObject("response", rv)
+	logObject(span, "response", rv)
 	return rv, err
 }
 func (t *traceNetworkServiceEndpointRegistryClient) Find(ctx context.Context, in *registry.NetworkServiceEndpointQuery, opts ...grpc.CallOption) (registry.NetworkServiceEndpointRegistry_FindClient, error) {
@@ -89,20 +90,20 @@ func (t *traceNetworkServiceEndpointRegistryClient) Find(ctx context.Context, in
 
 	ctx = withLog(span.Context(), span.Logger())
 
-	span.LogObject("find", in)
+	logObject(span, "find", in)
 
 	// Actually call the next
 	rv, err := t.traced.Find(ctx, in, opts...)
 	if err != nil {
 		if _, ok := err.(stackTracer); !ok {
-			err = errors.Wrapf(err, "Error returned from %s", operation)
+			err = nsmerrors.KeepStatus(errors.Wrapf(err, "Error returned from %s", operation))
 			span.LogErrorf("%+v", err)
 			return nil, err
 		}
 		span.LogErrorf("%v", err)
-		return nil, err
+		return nil, nsmerrors.KeepStatus(err)
 	}
-	span.LogObject("response", rv)
+	logObject(span, "response", rv)
 
 	return &traceNetworkServiceEndpointRegistryFindClient{NetworkServiceEndpointRegistry_FindClient: rv}, nil
 }
@@ -114,20 +115,20 @@ func (t *traceNetworkServiceEndpointRegistryClient) Unregister(ctx context.Conte
 
 	ctx = withLog(span.Context(), span.Logger())
 
-	span.LogObject("request", in)
+	logObject(span, "request", in)
 
 	// Actually call the next
 	rv, err := t.traced.Unregister(ctx, in, opts...)
 	if err != nil {
 		if _, ok := err.(stackTracer); !ok {
-			err = errors.Wrapf(err, "Error returned from %s", operation)
+			err = nsmerrors.KeepStatus(errors.Wrapf(err, "Error returned from %s", operation))
 			span.LogErrorf("%+v", err)
 			return nil, err
 		}
 		span.LogErrorf("%v", err)
-		return nil, err
+		return nil, nsmerrors.KeepStatus(err)
 	}
-	span.LogObject("response", rv)
+	logObject(span, "response", rv)
 	return rv, err
 }
 
@@ -147,19 +148,19 @@ func (t *traceNetworkServiceEndpointRegistryServer) Register(ctx context.Context
 
 	ctx = withLog(span.Context(), span.Logger())
 
-	span.LogObject("request", in)
+	logObject(span, "request", in)
 
 	rv, err := t.traced.Register(ctx, in)
 	if err != nil {
 		if _, ok := err.(stackTracer); !ok {
-			err = errors.Wrapf(err, "Error returned from %s", operation)
+			err = nsmerrors.KeepStatus(errors.Wrapf(err, "Error returned from %s", operation))
 			span.LogErrorf("%+v", err)
 			return nil, err
 		}
 		span.LogErrorf("%v", err)
-		return nil, err
+		return nil, nsmerrors.KeepStatus(err)
 	}
-	span.LogObject("response", rv)
+	logObject(span, "response", rv)
 	return rv, err
 }
 
@@ -172,18 +173,18 @@ func (t *traceNetworkServiceEndpointRegistryServer) Find(in *registry.NetworkSer
 	s = &traceNetworkServiceEndpointRegistryFindServer{
 		NetworkServiceEndpointRegistry_FindServer: streamcontext.NetworkServiceEndpointRegistryFindServer(ctx, s),
 	}
-	span.LogObject("find", in)
+	logObject(span, "find", in)
 
 	// Actually call the next
 	err := t.traced.Find(in, s)
 	if err != nil {
 		if _, ok := err.(stackTracer); !ok {
-			err = errors.Wrapf(err, "Error returned from %s", operation)
+			err = nsmerrors.KeepStatus(errors.Wrapf(err, "Error returned from %s", operation))
 			span.LogErrorf("%+v", err)
 			return err
 		}
 		span.LogErrorf("%v", err)
-		return err
+		return nsmerrors.KeepStatus(err)
 	}
 	return nil
 }
@@ -195,20 +196,20 @@ func (t *traceNetworkServiceEndpointRegistryServer) Unregister(ctx context.Conte
 
 	ctx = withLog(span.Context(), span.Logger())
 
-	span.LogObject("request", in)
+	logObject(span, "request", in)
 
 	// Actually call the next
 	rv, err := t.traced.Unregister(ctx, in)
 	if err != nil {
 		if _, ok := err.(stackTracer); !ok {
-			err = errors.Wrapf(err, "Error returned from %s", operation)
+			err = nsmerrors.KeepStatus(errors.Wrapf(err, "Error returned from %s", operation))
 			span.LogErrorf("%+v", err)
 			return nil, err
 		}
 		span.LogErrorf("%v", err)
-		return nil, err
+		return nil, nsmerrors.KeepStatus(err)
 	}
-	span.LogObject("response", rv)
+	logObject(span, "response", rv)
 	return rv, err
 }
 
@@ -225,18 +226,18 @@ func (t *traceNetworkServiceEndpointRegistryFindServer) Send(nse *registry.Netwo
 	operation := typeutils.GetFuncName(t.NetworkServiceEndpointRegistry_FindServer, "Send")
 	span := spanhelper.FromContext(t.Context(), operation)
 	defer span.Finish()
-	span.LogObject("network service endpoint", nse)
+	logObject(span, "network service endpoint", nse)
 	ctx := withLog(span.Context(), span.Logger())
 	s := streamcontext.NetworkServiceEndpointRegistryFindServer(ctx, t)
 	err := s.Send(nse)
 	if err != nil {
 		if _, ok := err.(stackTracer); !ok {
-			err = errors.Wrapf(err, "Error returned from %s", operation)
+			err = nsmerrors.KeepStatus(errors.Wrapf(err, "Error returned from %s", operation))
 			span.LogErrorf("%+v", err)
 			return err
 		}
 		span.LogErrorf("%v", err)
-		return err
+		return nsmerrors.KeepStatus(err)
 	}
 	return err
 }
@@ -18,8 +18,19 @@ package trace
 
 import (
 	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/redact"
+	"github.com/networkservicemesh/sdk/pkg/tools/spanhelper"
 )
 
 type stackTracer interface {
 	StackTrace() errors.StackTrace
 }
+
+// logObject logs value with the redacted fields to span if tracing is enabled
+func logObject(span spanhelper.SpanHelper, attribute string, value interface{}) {
+	if !spanhelper.IsTracingEnabled() {
+		return
+	}
+	span.LogObject(attribute, redact.Value(value))
+}
@@ -29,10 +29,31 @@ import (
 	"github.com/networkservicemesh/sdk/pkg/tools/serialize"
 )
 
+type unregisterNotifyKey struct{}
+
+// WithUnregisterNotify returns a context making the watching Find of the memory NetworkServiceRegistryServer served
+// with it call notify with the stored NetworkService on each Unregister of the NetworkService matching the query
+func WithUnregisterNotify(parent context.Context, notify func(ns *registry.NetworkService)) context.Context {
+	return context.WithValue(parent, unregisterNotifyKey{}, notify)
+}
+
+func unregisterNotify(ctx context.Context) func(ns *registry.NetworkService) {
+	if rv, ok := ctx.Value(unregisterNotifyKey{}).(func(ns *registry.NetworkService)); ok {
+		return rv
+	}
+	return nil
+}
+
+// nsEvent is the NetworkService registration or removal
+type nsEvent struct {
+	ns           *registry.NetworkService
+	unregistered bool
+}
+
 type networkServiceRegistryServer struct {
 	networkServices  NetworkServiceSyncMap
 	executor         serialize.Executor
-	eventChannels    []chan *registry.NetworkService
+	eventChannels    []chan *nsEvent
 	eventChannelSize int
 }
 
@@ -42,11 +63,7 @@ func (n *networkServiceRegistryServer) Register(ctx context.Context, ns *registr
 		return nil, err
 	}
 	n.networkServices.Store(r.Name, r)
-	n.executor.AsyncExec(func() {
-		for _, ch := range n.eventChannels {
-			ch <- r
-		}
-	})
+	n.sendEvent(&nsEvent{ns: r})
 	return r, nil
 }
 
@@ -63,7 +80,8 @@ func (n *networkServiceRegistryServer) Find(query *registry.NetworkServiceQuery,
 		return err
 	}
 	if query.Watch {
-		eventCh := make(chan *registry.NetworkService, n.eventChannelSize)
+		notify := unregisterNotify(s.Context())
+		eventCh := make(chan *nsEvent, n.eventChannelSize)
 		var index int
 		n.executor.AsyncExec(func() {
 			index = len(n.eventChannels)
@@ -81,15 +99,21 @@ func (n *networkServiceRegistryServer) Find(query *registry.NetworkServiceQuery,
 			case <-s.Context().Done():
 				return io.EOF
 			case event := <-eventCh:
-				if matchutils.MatchNetworkServices(query.NetworkService, event) {
-					if s.Context().Err() != nil {
-						return io.EOF
-					}
-					if err := s.Send(event); err != nil {
-						return err
+				// Removals are matched by the stored NetworkService, so the watchers see the removals they have
+				// seen the registrations of
+				if !matchutils.MatchNetworkServices(query.NetworkService, event.ns) {
+					return nil
+				}
+				if s.Context().Err() != nil {
+					return io.EOF
+				}
+				if event.unregistered {
+					if notify != nil {
+						notify(event.ns)
 					}
+					return nil
 				}
-				return nil
+				return s.Send(event.ns)
 			}
 		}
 		for {
@@ -108,7 +132,10 @@ func (n *networkServiceRegistryServer) Find(query *registry.NetworkServiceQuery,
 }
 
 func (n *networkServiceRegistryServer) Unregister(ctx context.Context, ns *registry.NetworkService) (*empty.Empty, error) {
-	n.networkServices.Delete(ns.Name)
+	if stored, ok := n.networkServices.Load(ns.Name); ok {
+		n.networkServices.Delete(ns.Name)
+		n.sendEvent(&nsEvent{ns: stored, unregistered: true})
+	}
 	return next.NetworkServiceRegistryServer(ctx).Unregister(ctx, ns)
 }
 
@@ -116,7 +143,17 @@ func (n *networkServiceRegistryServer) setEventChannelSize(l int) {
 	n.eventChannelSize = l
 }
 
-// NewNetworkServiceRegistryServer creates new memory based NetworkServiceRegistryServer
+func (n *networkServiceRegistryServer) sendEvent(event *nsEvent) {
+	n.executor.AsyncExec(func() {
+		for _, ch := range n.eventChannels {
+			ch <- event
+		}
+	})
+}
+
+// NewNetworkServiceRegistryServer creates new memory based NetworkServiceRegistryServer.
+// Watching Find streams receive the NetworkService on Register, the removals are reported to the streams served with
+// WithUnregisterNotify context.
 func NewNetworkServiceRegistryServer(options ...Option) registry.NetworkServiceRegistryServer {
 	r := &networkServiceRegistryServer{eventChannelSize: defaultEventChannelSize}
 	for _, o := range options {
@@ -107,3 +107,44 @@ func TestNetworkServiceRegistryServer_RegisterAndFindWatch(t *testing.T) {
 	cancel()
 	close(ch)
 }
+
+func TestNetworkServiceRegistryServer_UnregisterWatch(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	s := next.NewNetworkServiceRegistryServer(memory.NewNetworkServiceRegistryServer())
+
+	_, err := s.Register(context.Background(), &registry.NetworkService{
+		Name:    "a",
+		Payload: "IP",
+	})
+	require.NoError(t, err)
+
+	unregistered := make(chan *registry.NetworkService, 1)
+	ctx, cancel := context.WithCancel(memory.WithUnregisterNotify(context.Background(), func(ns *registry.NetworkService) {
+		unregistered <- ns
+	}))
+	ch := make(chan *registry.NetworkService, 1)
+	go func() {
+		_ = s.Find(&registry.NetworkServiceQuery{
+			Watch: true,
+			NetworkService: &registry.NetworkService{
+				Payload: "IP",
+			},
+		}, streamchannel.NewNetworkServiceFindServer(ctx, ch))
+	}()
+
+	ns := <-ch
+	require.Equal(t, "a", ns.Name)
+
+	// The removal is matched by the stored NetworkService
+	_, err = s.Unregister(context.Background(), &registry.NetworkService{
+		Name: "a",
+	})
+	require.NoError(t, err)
+	ns = <-unregistered
+	require.Equal(t, "a", ns.Name)
+	require.Equal(t, "IP", ns.Payload)
+	require.Empty(t, ch)
+
+	cancel()
+	close(ch)
+}
@@ -0,0 +1,142 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package admin provides a registry of the live state providers and an HTTP handler reporting their state as JSON,
+// so the internal state of the chain elements (connections, clients, sockets) can be inspected on a running node
+package admin
+
+import (
+	"context"
+	"sort"
+	"sync"
+)
+
+type contextKeyType string
+
+const registryKey contextKeyType = "AdminRegistry"
+
+// StateProvider provides the state to report, the state is marshaled to JSON
+type StateProvider interface {
+	State() interface{}
+}
+
+// StateFunc is a function adapter for StateProvider
+type StateFunc func() interface{}
+
+// State returns f()
+func (f StateFunc) State() interface{} {
+	return f()
+}
+
+// Registry is a set of the named state providers, it is a StateProvider itself so the registries can be nested
+type Registry struct {
+	mutex     sync.RWMutex
+	providers map[string]*registration
+}
+
+// registration is compared by pointer on unregister, since StateProvider may be uncomparable (e.g. StateFunc)
+type registration struct {
+	provider StateProvider
+}
+
+// NewRegistry creates an empty Registry
+func NewRegistry() *Registry {
+	return &Registry{
+		providers: make(map[string]*registration),
+	}
+}
+
+// Register registers provider with name replacing the previous one, returns the function unregistering it.
+// Register on nil Registry does nothing, so the elements can register unconditionally.
+func (r *Registry) Register(name string, provider StateProvider) (unregister func()) {
+	if r == nil {
+		return func() {}
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	reg := &registration{provider: provider}
+	r.providers[name] = reg
+	return func() {
+		r.mutex.Lock()
+		defer r.mutex.Unlock()
+
+		if r.providers[name] == reg {
+			delete(r.providers, name)
+		}
+	}
+}
+
+// Names returns sorted names of the registered providers
+func (r *Registry) Names() []string {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	rv := make([]string, 0, len(r.providers))
+	for name := range r.providers {
+		rv = append(rv, name)
+	}
+	sort.Strings(rv)
+	return rv
+}
+
+// Provider returns the provider registered with name
+func (r *Registry) Provider(name string) (StateProvider, bool) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	reg, ok := r.providers[name]
+	if !ok {
+		return nil, false
+	}
+	return reg.provider, true
+}
+
+// State returns map of the provider names to their states
+func (r *Registry) State() interface{} {
+	r.mutex.RLock()
+	providers := make(map[string]StateProvider, len(r.providers))
+	for name, reg := range r.providers {
+		providers[name] = reg.provider
+	}
+	r.mutex.RUnlock()
+
+	rv := make(map[string]interface{}, len(providers))
+	for name, provider := range providers {
+		rv[name] = provider.State()
+	}
+	return rv
+}
+
+// WithRegistry returns new context with the Registry for the elements created with this context
+func WithRegistry(parent context.Context, r *Registry) context.Context {
+	if parent == nil {
+		parent = context.TODO()
+	}
+	return context.WithValue(parent, registryKey, r)
+}
+
+// RegistryFromContext returns the Registry from the context or nil
+func RegistryFromContext(ctx context.Context) *Registry {
+	if ctx == nil {
+		return nil
+	}
+	if rv, ok := ctx.Value(registryKey).(*Registry); ok {
+		return rv
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin_test
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/admin"
+)
+
+func TestRegistry(t *testing.T) {
+	r := admin.NewRegistry()
+	nested := admin.NewRegistry()
+	r.Register("nested", nested)
+	nested.Register("value", admin.StateFunc(func() interface{} { return 1 }))
+	unregister := r.Register("list", admin.StateFunc(func() interface{} { return []string{"a"} }))
+
+	require.Equal(t, map[string]interface{}{
+		"nested": map[string]interface{}{"value": 1},
+		"list":   []string{"a"},
+	}, r.State())
+
+	unregister()
+	require.Equal(t, []string{"nested"}, r.Names())
+
+	// Register on nil Registry is no-op
+	var none *admin.Registry
+	none.Register("value", nested)()
+	require.Nil(t, admin.RegistryFromContext(context.Background()))
+	require.Equal(t, r, admin.RegistryFromContext(admin.WithRegistry(context.Background(), r)))
+}
+
+func TestListenAndServe(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	r := admin.NewRegistry()
+	r.Register("connections", admin.StateFunc(func() interface{} {
+		return map[string]string{"id": "ns"}
+	}))
+
+	dir, err := ioutil.TempDir("", "admin")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	u := &url.URL{Scheme: "unix", Path: filepath.Join(dir, "admin.sock")}
+	errCh := admin.ListenAndServe(ctx, u, admin.Handler(r), nil)
+
+	client := &http.Client{Transport: &http.Transport{
+		DisableKeepAlives: true,
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return new(net.Dialer).DialContext(ctx, "unix", u.Path)
+		},
+	}}
+
+	resp, err := client.Get("http://admin/")
+	require.NoError(t, err)
+	state := make(map[string]map[string]string)
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&state))
+	_ = resp.Body.Close()
+	require.Equal(t, map[string]map[string]string{"connections": {"id": "ns"}}, state)
+
+	resp, err = client.Get("http://admin/connections")
+	require.NoError(t, err)
+	connections := make(map[string]string)
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&connections))
+	_ = resp.Body.Close()
+	require.Equal(t, map[string]string{"id": "ns"}, connections)
+
+	resp, err = client.Get("http://admin/unknown")
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	cancel()
+	_, ok := <-errCh
+	require.False(t, ok)
+}
+
+func TestListenAndServe_TCPWithoutTLS(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	u := &url.URL{Scheme: "tcp", Host: "127.0.0.1:0"}
+	err, ok := <-admin.ListenAndServe(context.Background(), u, admin.Handler(admin.NewRegistry()), nil)
+	require.True(t, ok)
+	require.Error(t, err)
+}
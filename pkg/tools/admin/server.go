@@ -0,0 +1,108 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const shutdownTimeout = 5 * time.Second
+
+// Handler returns http.Handler reporting the state of r as JSON: GET / returns the state of all the providers,
+// GET /<name> returns the state of the provider registered with name
+func Handler(r *Registry) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			w.Header().Set("Allow", http.MethodGet)
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+
+		var state interface{}
+		if name := strings.Trim(req.URL.Path, "/"); name != "" {
+			provider, ok := r.Provider(name)
+			if !ok {
+				http.NotFound(w, req)
+				return
+			}
+			state = provider.State()
+		} else {
+			state = r.State()
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		_ = encoder.Encode(state)
+	})
+}
+
+// ListenAndServe serves handler on address until ctx is done. The TCP connections are secured with tlsConfig, e.g.
+// with spiffecreds.ServerTLSConfig built from the same sources as the gRPC API credentials, so the admin endpoint is
+// accessible only to the same authorized peers. tlsConfig may be nil only for the unix socket address protected by the
+// file permissions.
+// Returns a chan err which will receive an error and then be closed in the event that serving fails.
+func ListenAndServe(ctx context.Context, address *url.URL, handler http.Handler, tlsConfig *tls.Config) <-chan error {
+	errCh := make(chan error, 1)
+
+	network, target := "tcp", address.Host
+	if address.Scheme == "unix" {
+		network, target = "unix", address.Path
+	}
+	if tlsConfig == nil && network != "unix" {
+		errCh <- errors.Errorf("TLS config is required to serve admin endpoint on %s", address)
+		close(errCh)
+		return errCh
+	}
+	ln, err := net.Listen(network, target)
+	if err != nil {
+		errCh <- errors.Wrapf(err, "failed to listen on %s", address)
+		close(errCh)
+		return errCh
+	}
+	if network == "tcp" {
+		// We need to pass a real listener address, since we could specify random port.
+		address.Host = ln.Addr().String()
+	}
+	if tlsConfig != nil {
+		ln = tls.NewListener(ln, tlsConfig)
+	}
+
+	server := &http.Server{Handler: handler}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+	go func() {
+		if err := server.Serve(ln); err != http.ErrServerClosed {
+			errCh <- err
+		}
+		close(errCh)
+	}()
+	return errCh
+}
@@ -0,0 +1,51 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package closectx provides functions for stashing the reason of a connection Close in context.Contexts
+package closectx
+
+import (
+	"context"
+)
+
+// Close reasons
+const (
+	// ReasonClient - the connection is closed by the client
+	ReasonClient = "client"
+	// ReasonTimeout - the connection is closed because its path has expired
+	ReasonTimeout = "timeout"
+	// ReasonHeal - the connection is closed while being healed
+	ReasonHeal = "heal"
+)
+
+type contextKeyType string
+
+const (
+	reasonKey contextKeyType = "CloseReason"
+)
+
+// WithReason - returns a child context with the reason of the Close
+func WithReason(parent context.Context, reason string) context.Context {
+	return context.WithValue(parent, reasonKey, reason)
+}
+
+// Reason - returns the reason of the Close stored in the context, ReasonClient by default
+func Reason(ctx context.Context) string {
+	if rv, ok := ctx.Value(reasonKey).(string); ok {
+		return rv
+	}
+	return ReasonClient
+}
@@ -24,6 +24,7 @@ import (
 	"strconv"
 
 	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"github.com/uber/jaeger-client-go"
 	"github.com/uber/jaeger-client-go/config"
@@ -60,17 +61,19 @@ func (*emptyCloser) Close() error {
 	return nil
 }
 
-// InitJaeger -  returns an instance of Jaeger Tracer that samples 100% of traces and logs all spans to stdout.
-func InitJaeger(service string) io.Closer {
+// InitJaeger -  returns an instance of Jaeger Tracer that samples 100% of traces and logs all spans to stdout. If the
+// tracer cannot be created, the global tracer is set to opentracing.NoopTracer and the error is returned.
+func InitJaeger(service string) (io.Closer, error) {
 	if !IsOpentracingEnabled() {
-		return &emptyCloser{}
+		return &emptyCloser{}, nil
 	}
 	if opentracing.IsGlobalTracerRegistered() {
 		logrus.Warningf("global opentracer is already initialized")
 	}
 	cfg, err := config.FromEnv()
 	if err != nil {
-		panic(fmt.Sprintf("ERROR: cannot create Jaeger configuration: %v\n", err))
+		opentracing.SetGlobalTracer(opentracing.NoopTracer{})
+		return &emptyCloser{}, errors.Wrap(err, "cannot create Jaeger configuration")
 	}
 
 	if cfg.ServiceName == "" {
@@ -95,9 +98,9 @@ func InitJaeger(service string) io.Closer {
 	logrus.Infof("Creating logger from config: %v", cfg)
 	tracer, closer, err := cfg.NewTracer(config.Logger(jaeger.StdLogger))
 	if err != nil {
-		logrus.Errorf("ERROR: cannot init Jaeger: %v\n", err)
-		return &emptyCloser{}
+		opentracing.SetGlobalTracer(opentracing.NoopTracer{})
+		return &emptyCloser{}, errors.Wrap(err, "cannot init Jaeger")
 	}
 	opentracing.SetGlobalTracer(tracer)
-	return closer
+	return closer, nil
 }
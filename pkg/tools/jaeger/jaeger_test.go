@@ -0,0 +1,40 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jaeger_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/jaeger"
+)
+
+func TestInitJaeger_InvalidConfig(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	require.NoError(t, os.Setenv("JAEGER_SAMPLER_PARAM", "invalid"))
+	defer func() { _ = os.Unsetenv("JAEGER_SAMPLER_PARAM") }()
+
+	closer, err := jaeger.InitJaeger("test")
+	require.Error(t, err)
+	require.NoError(t, closer.Close())
+	require.IsType(t, opentracing.NoopTracer{}, opentracing.GlobalTracer())
+}
@@ -0,0 +1,135 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package jsonpatch provides computing of JSON Patch (RFC 6902) between JSON representations of two values
+package jsonpatch
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Patch operations
+const (
+	AddOp     = "add"
+	RemoveOp  = "remove"
+	ReplaceOp = "replace"
+)
+
+// Operation is a JSON Patch operation
+type Operation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// Patch is a JSON Patch, its operations should be applied in order
+type Patch []Operation
+
+// Diff returns Patch transforming JSON representation of from to JSON representation of to
+func Diff(from, to interface{}) (Patch, error) {
+	fromDoc, err := toDocument(from)
+	if err != nil {
+		return nil, err
+	}
+	toDoc, err := toDocument(to)
+	if err != nil {
+		return nil, err
+	}
+	var patch Patch
+	diff("", fromDoc, toDoc, &patch)
+	return patch, nil
+}
+
+func toDocument(value interface{}) (doc interface{}, err error) {
+	bytes, err := json.Marshal(value)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to marshal %T", value)
+	}
+	if err := json.Unmarshal(bytes, &doc); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal %T", value)
+	}
+	return doc, nil
+}
+
+func diff(path string, from, to interface{}, patch *Patch) {
+	switch fromValue := from.(type) {
+	case map[string]interface{}:
+		if toValue, ok := to.(map[string]interface{}); ok {
+			diffObjects(path, fromValue, toValue, patch)
+			return
+		}
+	case []interface{}:
+		if toValue, ok := to.([]interface{}); ok {
+			diffArrays(path, fromValue, toValue, patch)
+			return
+		}
+	}
+	if !reflect.DeepEqual(from, to) {
+		*patch = append(*patch, Operation{Op: ReplaceOp, Path: path, Value: to})
+	}
+}
+
+func diffObjects(path string, from, to map[string]interface{}, patch *Patch) {
+	for _, key := range sortedKeys(from) {
+		if _, ok := to[key]; !ok {
+			*patch = append(*patch, Operation{Op: RemoveOp, Path: path + "/" + escape(key)})
+		}
+	}
+	for _, key := range sortedKeys(to) {
+		if fromValue, ok := from[key]; ok {
+			diff(path+"/"+escape(key), fromValue, to[key], patch)
+			continue
+		}
+		*patch = append(*patch, Operation{Op: AddOp, Path: path + "/" + escape(key), Value: to[key]})
+	}
+}
+
+func diffArrays(path string, from, to []interface{}, patch *Patch) {
+	common := len(from)
+	if len(to) < common {
+		common = len(to)
+	}
+	for i := 0; i < common; i++ {
+		diff(path+"/"+strconv.Itoa(i), from[i], to[i], patch)
+	}
+	for i := common; i < len(to); i++ {
+		*patch = append(*patch, Operation{Op: AddOp, Path: path + "/" + strconv.Itoa(i), Value: to[i]})
+	}
+	// Remove from the end, so the indexes of the remaining elements are not changed
+	for i := len(from) - 1; i >= common; i-- {
+		*patch = append(*patch, Operation{Op: RemoveOp, Path: path + "/" + strconv.Itoa(i)})
+	}
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// escape escapes JSON Pointer (RFC 6901) reference token
+func escape(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
@@ -0,0 +1,69 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonpatch_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/jsonpatch"
+)
+
+type value struct {
+	Name   string            `json:"name,omitempty"`
+	Labels map[string]string `json:"labels,omitempty"`
+	Items  []string          `json:"items,omitempty"`
+}
+
+func TestDiff(t *testing.T) {
+	from := &value{
+		Name:   "a",
+		Labels: map[string]string{"app": "nsc", "a/b~c": "1"},
+		Items:  []string{"x", "y", "z"},
+	}
+	to := &value{
+		Labels: map[string]string{"app": "nse", "zone": "1"},
+		Items:  []string{"x", "w"},
+	}
+
+	patch, err := jsonpatch.Diff(from, to)
+	require.NoError(t, err)
+	require.Equal(t, jsonpatch.Patch{
+		{Op: jsonpatch.RemoveOp, Path: "/name"},
+		{Op: jsonpatch.ReplaceOp, Path: "/items/1", Value: "w"},
+		{Op: jsonpatch.RemoveOp, Path: "/items/2"},
+		{Op: jsonpatch.RemoveOp, Path: "/labels/a~1b~0c"},
+		{Op: jsonpatch.ReplaceOp, Path: "/labels/app", Value: "nse"},
+		{Op: jsonpatch.AddOp, Path: "/labels/zone", Value: "1"},
+	}, patch)
+
+	patch, err = jsonpatch.Diff(from, from)
+	require.NoError(t, err)
+	require.Empty(t, patch)
+
+	patch, err = jsonpatch.Diff(&value{Items: []string{"x"}}, &value{Items: []string{"x", "y", "z"}})
+	require.NoError(t, err)
+	require.Equal(t, jsonpatch.Patch{
+		{Op: jsonpatch.AddOp, Path: "/items/1", Value: "y"},
+		{Op: jsonpatch.AddOp, Path: "/items/2", Value: "z"},
+	}, patch)
+
+	patch, err = jsonpatch.Diff("a", 1)
+	require.NoError(t, err)
+	require.Equal(t, jsonpatch.Patch{{Op: jsonpatch.ReplaceOp, Path: "", Value: 1.}}, patch)
+}
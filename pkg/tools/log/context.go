@@ -15,7 +15,7 @@
 // limitations under the License.
 
 // Package log provides functions for having a *logrus.Entry per Context
-// This allows the for context associative logging
+// This allows the for context associative logging, and a leveled Logger with the levels per component
 package log
 
 import (
@@ -0,0 +1,165 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// Level is a logging level
+type Level int
+
+// Logging levels, the messages with a level below the component level are dropped
+const (
+	DebugLevel Level = iota
+	InfoLevel
+	WarnLevel
+	ErrorLevel
+)
+
+// DefaultComponent is the name of the component used for the components without level
+const DefaultComponent = "default"
+
+var levelNames = map[Level]string{
+	DebugLevel: "debug",
+	InfoLevel:  "info",
+	WarnLevel:  "warn",
+	ErrorLevel: "error",
+}
+
+func (l Level) String() string {
+	if name, ok := levelNames[l]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+// ParseLevel parses the level name: debug, info, warn (warning) or error
+func ParseLevel(name string) (Level, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "debug", "trace":
+		return DebugLevel, nil
+	case "info":
+		return InfoLevel, nil
+	case "warn", "warning":
+		return WarnLevel, nil
+	case "error", "fatal", "panic":
+		return ErrorLevel, nil
+	}
+	return 0, errors.Errorf("unknown log level: %s", name)
+}
+
+// ParseLevels parses comma or new line separated "component=level" pairs, e.g. "default=info,heal=debug"
+func ParseLevels(s string) (map[string]Level, error) {
+	levels := make(map[string]Level)
+	for _, pair := range strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == '\n' }) {
+		pair = strings.TrimSpace(pair)
+		if pair == "" || strings.HasPrefix(pair, "#") {
+			continue
+		}
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) != 2 || strings.TrimSpace(kv[0]) == "" {
+			return nil, errors.Errorf("invalid component level: %s", pair)
+		}
+		level, err := ParseLevel(kv[1])
+		if err != nil {
+			return nil, err
+		}
+		levels[strings.TrimSpace(kv[0])] = level
+	}
+	return levels, nil
+}
+
+var componentLevels = struct {
+	sync.RWMutex
+	levels map[string]Level
+}{
+	levels: make(map[string]Level),
+}
+
+// SetLevel sets the level of the component, DefaultComponent level is used for the components without level
+func SetLevel(component string, level Level) {
+	componentLevels.Lock()
+	defer componentLevels.Unlock()
+
+	componentLevels.levels[component] = level
+}
+
+// ResetLevel removes the level of the component
+func ResetLevel(component string) {
+	componentLevels.Lock()
+	defer componentLevels.Unlock()
+
+	delete(componentLevels.levels, component)
+}
+
+// SetLevels replaces all the component levels with levels
+func SetLevels(levels map[string]Level) {
+	componentLevels.Lock()
+	defer componentLevels.Unlock()
+
+	componentLevels.levels = make(map[string]Level, len(levels))
+	for component, level := range levels {
+		componentLevels.levels[component] = level
+	}
+}
+
+// Levels returns a copy of the component levels
+func Levels() map[string]Level {
+	componentLevels.RLock()
+	defer componentLevels.RUnlock()
+
+	rv := make(map[string]Level, len(componentLevels.levels))
+	for component, level := range componentLevels.levels {
+		rv[component] = level
+	}
+	return rv
+}
+
+// GetLevel returns the level of the component, DefaultComponent level or InfoLevel
+func GetLevel(component string) Level {
+	if level, ok := lookupLevel(component); ok {
+		return level
+	}
+	return InfoLevel
+}
+
+// lookupLevel returns the level of the component or DefaultComponent level, false if neither is set
+func lookupLevel(component string) (Level, bool) {
+	componentLevels.RLock()
+	defer componentLevels.RUnlock()
+
+	if level, ok := componentLevels.levels[component]; ok {
+		return level, true
+	}
+	level, ok := componentLevels.levels[DefaultComponent]
+	return level, ok
+}
+
+// formatLevels formats levels as ParseLevels input sorted by component
+func formatLevels(levels map[string]Level) string {
+	pairs := make([]string, 0, len(levels))
+	for component, level := range levels {
+		pairs = append(pairs, component+"="+level.String())
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
@@ -0,0 +1,235 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	loggerFields contextKeyType = "LoggerFields"
+
+	// ComponentField is the name of the field holding the component name
+	ComponentField = "component"
+	// ConnectionIDField is the name of the field holding the connection ID
+	ConnectionIDField = "connection_id"
+	// NetworkServiceField is the name of the field holding the network service name
+	NetworkServiceField = "network_service"
+)
+
+// Logger is a leveled logger with the component level applied
+type Logger interface {
+	Debugf(format string, args ...interface{})
+	Infof(format string, args ...interface{})
+	Warnf(format string, args ...interface{})
+	Errorf(format string, args ...interface{})
+	// WithField returns Logger with {key:value} added to the fields
+	WithField(key string, value interface{}) Logger
+}
+
+// Backend writes the log messages passed the level check, zap or logr adapters can be plugged in with SetBackend
+type Backend interface {
+	Log(ctx context.Context, level Level, fields map[string]interface{}, msg string)
+}
+
+// BackendFunc is a function adapter for Backend
+type BackendFunc func(ctx context.Context, level Level, fields map[string]interface{}, msg string)
+
+// Log calls f(ctx, level, fields, msg)
+func (f BackendFunc) Log(ctx context.Context, level Level, fields map[string]interface{}, msg string) {
+	f(ctx, level, fields, msg)
+}
+
+// LogrusBackend returns Backend writing to the context logrus.Entry (see Entry)
+func LogrusBackend() Backend {
+	return BackendFunc(func(ctx context.Context, level Level, fields map[string]interface{}, msg string) {
+		entry := Entry(ctx).WithFields(fields)
+		// The component level is already checked, so the message should pass the logrus level
+		if !entry.Logger.IsLevelEnabled(level.LogrusLevel()) {
+			entry.Logger = copyLogger(entry.Logger, level.LogrusLevel())
+		}
+		entry.Log(level.LogrusLevel(), msg)
+	})
+}
+
+var backend = struct {
+	sync.RWMutex
+	Backend
+}{
+	Backend: LogrusBackend(),
+}
+
+// SetBackend sets the Backend used by all the Loggers, LogrusBackend is used by default
+func SetBackend(b Backend) {
+	backend.Lock()
+	defer backend.Unlock()
+
+	backend.Backend = b
+}
+
+func currentBackend() Backend {
+	backend.RLock()
+	defer backend.RUnlock()
+
+	return backend.Backend
+}
+
+// WithComponent returns new context with the component name used by FromContext for level and fields
+func WithComponent(parent context.Context, component string) context.Context {
+	return withLoggerField(parent, ComponentField, component)
+}
+
+// WithConnection returns new context with the connection ID and the network service attached to the log messages
+func WithConnection(parent context.Context, connectionID, networkService string) context.Context {
+	ctx := withLoggerField(parent, ConnectionIDField, connectionID)
+	if networkService != "" {
+		ctx = withLoggerField(ctx, NetworkServiceField, networkService)
+	}
+	return ctx
+}
+
+// Component returns the component name from the context or DefaultComponent
+func Component(ctx context.Context) string {
+	if component, ok := fieldsFromContext(ctx)[ComponentField].(string); ok && component != "" {
+		return component
+	}
+	return DefaultComponent
+}
+
+// FromContext returns Logger for the context component with the context fields
+func FromContext(ctx context.Context) Logger {
+	if ctx == nil {
+		ctx = context.TODO()
+	}
+	return &logger{
+		ctx:       ctx,
+		component: Component(ctx),
+		fields:    fieldsFromContext(ctx),
+	}
+}
+
+// LogrusLevel returns the corresponding logrus.Level
+func (l Level) LogrusLevel() logrus.Level {
+	switch l {
+	case DebugLevel:
+		return logrus.DebugLevel
+	case WarnLevel:
+		return logrus.WarnLevel
+	case ErrorLevel:
+		return logrus.ErrorLevel
+	default:
+		return logrus.InfoLevel
+	}
+}
+
+// ApplyLevel returns logrus.FieldLogger with the component level applied, if the component has a level set (see
+// SetLevel). l should not share its logrus.Logger with other FieldLoggers.
+func ApplyLevel(l logrus.FieldLogger, component string) logrus.FieldLogger {
+	level, ok := lookupLevel(component)
+	if !ok {
+		return l
+	}
+	switch v := l.(type) {
+	case *logrus.Logger:
+		v.SetLevel(level.LogrusLevel())
+	case *logrus.Entry:
+		v.Logger.SetLevel(level.LogrusLevel())
+	}
+	return l
+}
+
+type logger struct {
+	ctx       context.Context
+	component string
+	fields    map[string]interface{}
+}
+
+func (l *logger) Debugf(format string, args ...interface{}) {
+	l.log(DebugLevel, format, args...)
+}
+
+func (l *logger) Infof(format string, args ...interface{}) {
+	l.log(InfoLevel, format, args...)
+}
+
+func (l *logger) Warnf(format string, args ...interface{}) {
+	l.log(WarnLevel, format, args...)
+}
+
+func (l *logger) Errorf(format string, args ...interface{}) {
+	l.log(ErrorLevel, format, args...)
+}
+
+func (l *logger) WithField(key string, value interface{}) Logger {
+	fields := make(map[string]interface{}, len(l.fields)+1)
+	for k, v := range l.fields {
+		fields[k] = v
+	}
+	fields[key] = value
+	return &logger{
+		ctx:       l.ctx,
+		component: l.component,
+		fields:    fields,
+	}
+}
+
+func (l *logger) log(level Level, format string, args ...interface{}) {
+	if level < GetLevel(l.component) {
+		return
+	}
+	currentBackend().Log(l.ctx, level, l.fields, fmt.Sprintf(format, args...))
+}
+
+func withLoggerField(parent context.Context, key string, value interface{}) context.Context {
+	if parent == nil {
+		parent = context.TODO()
+	}
+	parentFields := fieldsFromContext(parent)
+	fields := make(map[string]interface{}, len(parentFields)+1)
+	for k, v := range parentFields {
+		fields[k] = v
+	}
+	fields[key] = value
+	return context.WithValue(parent, loggerFields, fields)
+}
+
+func fieldsFromContext(ctx context.Context) map[string]interface{} {
+	if ctx == nil {
+		return nil
+	}
+	fields, _ := ctx.Value(loggerFields).(map[string]interface{})
+	return fields
+}
+
+func copyLogger(l *logrus.Logger, level logrus.Level) *logrus.Logger {
+	rv := &logrus.Logger{
+		Out:          l.Out,
+		Formatter:    l.Formatter,
+		Hooks:        make(logrus.LevelHooks),
+		Level:        level,
+		ExitFunc:     l.ExitFunc,
+		ReportCaller: l.ReportCaller,
+	}
+	for k, v := range l.Hooks {
+		rv.Hooks[k] = v
+	}
+	return rv
+}
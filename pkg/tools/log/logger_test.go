@@ -0,0 +1,137 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+type message struct {
+	level  log.Level
+	fields map[string]interface{}
+	msg    string
+}
+
+func captureBackend(messages *[]message) log.Backend {
+	return log.BackendFunc(func(_ context.Context, level log.Level, fields map[string]interface{}, msg string) {
+		*messages = append(*messages, message{level: level, fields: fields, msg: msg})
+	})
+}
+
+func TestParseLevels(t *testing.T) {
+	levels, err := log.ParseLevels("default=warn, heal=debug\n# comment\ntimeout=error")
+	require.NoError(t, err)
+	require.Equal(t, map[string]log.Level{
+		"default": log.WarnLevel,
+		"heal":    log.DebugLevel,
+		"timeout": log.ErrorLevel,
+	}, levels)
+
+	_, err = log.ParseLevels("heal=verbose")
+	require.Error(t, err)
+	_, err = log.ParseLevels("heal")
+	require.Error(t, err)
+}
+
+func TestFromContext(t *testing.T) {
+	var messages []message
+	log.SetBackend(captureBackend(&messages))
+	defer log.SetBackend(log.LogrusBackend())
+	log.SetLevels(map[string]log.Level{
+		log.DefaultComponent: log.WarnLevel,
+		"heal":               log.DebugLevel,
+	})
+	defer log.SetLevels(nil)
+
+	ctx := log.WithConnection(context.Background(), "conn-1", "ns-1")
+
+	log.FromContext(ctx).Infof("dropped")
+	log.FromContext(ctx).Warnf("default %d", 1)
+	log.FromContext(log.WithComponent(ctx, "heal")).WithField("attempt", 2).Debugf("heal %d", 2)
+
+	require.Equal(t, []message{
+		{
+			level: log.WarnLevel,
+			fields: map[string]interface{}{
+				log.ConnectionIDField:   "conn-1",
+				log.NetworkServiceField: "ns-1",
+			},
+			msg: "default 1",
+		},
+		{
+			level: log.DebugLevel,
+			fields: map[string]interface{}{
+				log.ComponentField:      "heal",
+				log.ConnectionIDField:   "conn-1",
+				log.NetworkServiceField: "ns-1",
+				"attempt":               2,
+			},
+			msg: "heal 2",
+		},
+	}, messages)
+}
+
+func TestLevelHandler(t *testing.T) {
+	defer log.SetLevels(nil)
+
+	server := httptest.NewServer(log.LevelHandler())
+	defer server.Close()
+
+	resp, err := http.Post(fmt.Sprintf("%s?component=heal&level=debug", server.URL), "", nil)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, log.DebugLevel, log.GetLevel("heal"))
+
+	resp, err = http.Post(fmt.Sprintf("%s?component=heal&level=verbose", server.URL), "", nil)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp, err = http.Get(server.URL)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	levels := make(map[string]string)
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&levels))
+	require.Equal(t, map[string]string{"heal": "debug"}, levels)
+}
+
+func TestLoadLevels(t *testing.T) {
+	defer log.SetLevels(nil)
+
+	dir, err := ioutil.TempDir("", "log")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+	path := filepath.Join(dir, "levels")
+	require.NoError(t, ioutil.WriteFile(path, []byte("default=error\nheal=info\n"), 0600))
+
+	require.NoError(t, log.LoadLevels(path))
+	require.Equal(t, log.ErrorLevel, log.GetLevel("timeout"))
+	require.Equal(t, log.InfoLevel, log.GetLevel("heal"))
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/pkg/errors"
+)
+
+// LoadLevels replaces the component levels with the levels from the file in ParseLevels format
+func LoadLevels(path string) error {
+	data, err := ioutil.ReadFile(path) // #nosec
+	if err != nil {
+		return errors.Wrapf(err, "failed to read log levels from %s", path)
+	}
+	levels, err := ParseLevels(string(data))
+	if err != nil {
+		return errors.Wrapf(err, "failed to parse log levels from %s", path)
+	}
+	SetLevels(levels)
+	return nil
+}
+
+// ReloadOnSignal - reloads the component levels from the file with LoadLevels every time one of the specified
+//                  signals 'sig' is received until ctx is done. If sig is not provided, defaults to syscall.SIGHUP
+func ReloadOnSignal(ctx context.Context, path string, sig ...os.Signal) {
+	if len(sig) == 0 {
+		sig = []os.Signal{syscall.SIGHUP}
+	}
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, sig...)
+	go func() {
+		defer signal.Stop(c)
+		for {
+			select {
+			case s := <-c:
+				if err := LoadLevels(path); err != nil {
+					Entry(ctx).Errorf("Caught signal %s, failed to reload log levels: %+v", s, err)
+					continue
+				}
+				Entry(ctx).Infof("Caught signal %s, log levels reloaded: %s", s, formatLevels(Levels()))
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
@@ -0,0 +1,99 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// DefaultBuckets are default histogram buckets in seconds
+var DefaultBuckets = []float64{.001, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// DefaultRegistry is the registry used by the chain elements by default
+var DefaultRegistry = NewRegistry()
+
+// Registry is a prometheus.Registry returning the already registered metric vector for the same name instead of
+// failing, so the chain elements can share the metric vectors
+type Registry struct {
+	*prometheus.Registry
+
+	mutex      sync.Mutex
+	collectors map[string]*collector
+}
+
+type collector struct {
+	prometheus.Collector
+	signature string
+}
+
+// NewRegistry creates a new Registry
+func NewRegistry() *Registry {
+	return &Registry{
+		Registry:   prometheus.NewRegistry(),
+		collectors: make(map[string]*collector),
+	}
+}
+
+func (r *Registry) getOrRegister(name, signature string, create func() prometheus.Collector) prometheus.Collector {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if c, ok := r.collectors[name]; ok {
+		if c.signature != signature {
+			panic(fmt.Sprintf("metric %s is already registered as %s", name, c.signature))
+		}
+		return c.Collector
+	}
+	c := &collector{
+		Collector: create(),
+		signature: signature,
+	}
+	r.MustRegister(c.Collector)
+	r.collectors[name] = c
+	return c.Collector
+}
+
+// Counter returns the counter vector with the given name, registering it if needed. Panics if the name is already
+// registered with another type or label names.
+func (r *Registry) Counter(name, help string, labelNames ...string) *prometheus.CounterVec {
+	return r.getOrRegister(name, fmt.Sprintf("counter with labels %v", labelNames), func() prometheus.Collector {
+		return prometheus.NewCounterVec(prometheus.CounterOpts{Name: name, Help: help}, labelNames)
+	}).(*prometheus.CounterVec)
+}
+
+// Gauge returns the gauge vector with the given name, registering it if needed. Panics if the name is already
+// registered with another type or label names.
+func (r *Registry) Gauge(name, help string, labelNames ...string) *prometheus.GaugeVec {
+	return r.getOrRegister(name, fmt.Sprintf("gauge with labels %v", labelNames), func() prometheus.Collector {
+		return prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: name, Help: help}, labelNames)
+	}).(*prometheus.GaugeVec)
+}
+
+// Histogram returns the histogram vector with the given name, registering it if needed. Panics if the name is
+// already registered with another type, label names or buckets.
+func (r *Registry) Histogram(name, help string, buckets []float64, labelNames ...string) *prometheus.HistogramVec {
+	sorted := append([]float64(nil), buckets...)
+	sort.Float64s(sorted)
+	signature := fmt.Sprintf("histogram with labels %v and buckets %v", labelNames, sorted)
+	return r.getOrRegister(name, signature, func() prometheus.Collector {
+		return prometheus.NewHistogramVec(prometheus.HistogramOpts{Name: name, Help: help, Buckets: sorted}, labelNames)
+	}).(*prometheus.HistogramVec)
+}
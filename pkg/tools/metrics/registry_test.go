@@ -0,0 +1,92 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/metrics"
+)
+
+func TestRegistry(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	registry := metrics.NewRegistry()
+
+	counter := registry.Counter("calls_total", "Total calls", "method")
+	counter.WithLabelValues("Request").Inc()
+	counter.WithLabelValues("Close").Add(2)
+	require.Equal(t, counter, registry.Counter("calls_total", "Other help", "method"))
+
+	registry.Gauge("active", "Active connections", "name").WithLabelValues("a").Set(3)
+
+	histogram := registry.Histogram("duration_seconds", "Duration", []float64{1, 0.1})
+	histogram.WithLabelValues().Observe(0.05)
+	histogram.WithLabelValues().Observe(0.5)
+	histogram.WithLabelValues().Observe(5)
+
+	require.NoError(t, testutil.GatherAndCompare(registry, strings.NewReader(`# HELP active Active connections
+# TYPE active gauge
+active{name="a"} 3
+# HELP calls_total Total calls
+# TYPE calls_total counter
+calls_total{method="Close"} 2
+calls_total{method="Request"} 1
+# HELP duration_seconds Duration
+# TYPE duration_seconds histogram
+duration_seconds_bucket{le="0.1"} 1
+duration_seconds_bucket{le="1"} 2
+duration_seconds_bucket{le="+Inf"} 3
+duration_seconds_sum 5.55
+duration_seconds_count 3
+`)))
+}
+
+func TestRegistry_Mismatch(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	registry := metrics.NewRegistry()
+	registry.Counter("calls_total", "Total calls", "method")
+	registry.Histogram("duration_seconds", "Duration", []float64{0.1, 1})
+
+	require.Panics(t, func() { registry.Counter("calls_total", "Total calls", "name") })
+	require.Panics(t, func() { registry.Counter("calls_total", "Total calls") })
+	require.Panics(t, func() { registry.Gauge("calls_total", "Total calls", "method") })
+	require.Panics(t, func() { registry.Histogram("duration_seconds", "Duration", []float64{1, 10}) })
+	require.NotPanics(t, func() { registry.Histogram("duration_seconds", "Duration", []float64{1, 0.1}) })
+}
+
+func TestHandler(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	registry := metrics.NewRegistry()
+	registry.Counter("calls_total", "Total calls").WithLabelValues().Inc()
+
+	recorder := httptest.NewRecorder()
+	metrics.Handler(registry).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Contains(t, recorder.Header().Get("Content-Type"), "text/plain")
+	require.Contains(t, recorder.Body.String(), "calls_total 1\n")
+}
@@ -0,0 +1,122 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nsmerrors
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/status"
+)
+
+type stackTracer interface {
+	StackTrace() errors.StackTrace
+}
+
+// Error is a classified SDK error, it is converted by gRPC to the status with Reason code and errdetails.ErrorInfo
+type Error struct {
+	reason   Reason
+	err      error
+	cause    error
+	metadata map[string]string
+}
+
+// New returns Error with reason and the formatted message
+func New(reason Reason, format string, args ...interface{}) *Error {
+	return &Error{
+		reason: reason,
+		err:    errors.Errorf(format, args...),
+	}
+}
+
+// Wrap returns Error with reason and the formatted message classifying err
+func Wrap(err error, reason Reason, format string, args ...interface{}) *Error {
+	return &Error{
+		reason: reason,
+		err:    errors.Wrapf(err, format, args...),
+		cause:  err,
+	}
+}
+
+// WithMetadata adds {key: value} to errdetails.ErrorInfo metadata, returns e
+func (e *Error) WithMetadata(key, value string) *Error {
+	if e.metadata == nil {
+		e.metadata = make(map[string]string)
+	}
+	e.metadata[key] = value
+	return e
+}
+
+// Reason returns the failure reason
+func (e *Error) Reason() Reason {
+	return e.reason
+}
+
+// Metadata returns a copy of errdetails.ErrorInfo metadata
+func (e *Error) Metadata() map[string]string {
+	rv := make(map[string]string, len(e.metadata))
+	for k, v := range e.metadata {
+		rv[k] = v
+	}
+	return rv
+}
+
+func (e *Error) Error() string {
+	return e.err.Error()
+}
+
+// Cause returns the classified error or nil
+func (e *Error) Cause() error {
+	return e.cause
+}
+
+// Unwrap returns the classified error or nil
+func (e *Error) Unwrap() error {
+	return e.cause
+}
+
+// StackTrace returns the stack trace of the Error creation
+func (e *Error) StackTrace() errors.StackTrace {
+	if st, ok := e.err.(stackTracer); ok {
+		return st.StackTrace()
+	}
+	return nil
+}
+
+// Format formats Error the same way as github.com/pkg/errors does, "%+v" prints the stack trace
+func (e *Error) Format(s fmt.State, verb rune) {
+	if f, ok := e.err.(fmt.Formatter); ok {
+		f.Format(s, verb)
+		return
+	}
+	_, _ = fmt.Fprint(s, e.err.Error())
+}
+
+// GRPCStatus returns the status with Reason code and errdetails.ErrorInfo details
+func (e *Error) GRPCStatus() *status.Status {
+	s := status.New(e.reason.Code(), e.Error())
+	info := &errdetails.ErrorInfo{
+		Reason:   string(e.reason),
+		Domain:   Domain,
+		Metadata: e.Metadata(),
+	}
+	if rv, err := s.WithDetails(info); err == nil {
+		return rv
+	}
+	return s
+}
@@ -0,0 +1,78 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nsmerrors_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/nsmerrors"
+)
+
+func TestError_GRPCStatus(t *testing.T) {
+	err := nsmerrors.New(nsmerrors.ReasonNoEndpoint, "failed to find endpoint for %s", "ns").
+		WithMetadata("network_service", "ns")
+
+	require.Equal(t, "failed to find endpoint for ns", err.Error())
+	require.Equal(t, codes.NotFound, status.Code(err))
+	require.NotEmpty(t, err.StackTrace())
+	require.Contains(t, fmt.Sprintf("%+v", err), "TestError_GRPCStatus")
+
+	// Simulate the status received over gRPC
+	received := status.ErrorProto(status.Convert(err).Proto())
+	require.Equal(t, codes.NotFound, status.Code(received))
+	reason, ok := nsmerrors.ReasonOf(received)
+	require.True(t, ok)
+	require.Equal(t, nsmerrors.ReasonNoEndpoint, reason)
+}
+
+func TestWrap(t *testing.T) {
+	cause := errors.New("connection refused")
+	err := nsmerrors.Wrap(cause, nsmerrors.ReasonDialFailed, "unable to dial %s", "tcp://1.1.1.1:5000")
+
+	require.Equal(t, "unable to dial tcp://1.1.1.1:5000: connection refused", err.Error())
+	require.Equal(t, cause, errors.Cause(err))
+	require.Equal(t, codes.Unavailable, nsmerrors.Code(err))
+}
+
+func TestKeepStatus(t *testing.T) {
+	err := errors.Wrap(nsmerrors.New(nsmerrors.ReasonIPAMExhausted, "ipam allocation pool depleted"), "Error returned from ipam")
+	require.Equal(t, codes.Unknown, status.Code(err))
+	require.Equal(t, codes.ResourceExhausted, nsmerrors.Code(err))
+
+	kept := nsmerrors.KeepStatus(err)
+	s := status.Convert(kept)
+	require.Equal(t, codes.ResourceExhausted, s.Code())
+	require.Equal(t, err.Error(), s.Message())
+	reason, ok := nsmerrors.ReasonOf(kept)
+	require.True(t, ok)
+	require.Equal(t, nsmerrors.ReasonIPAMExhausted, reason)
+
+	// gRPC status errors keep their codes as well
+	kept = nsmerrors.KeepStatus(errors.Wrap(status.Error(codes.PermissionDenied, "no sufficient privileges"), "Error returned from authorize"))
+	require.Equal(t, codes.PermissionDenied, status.Code(kept))
+
+	plain := errors.New("plain")
+	require.Equal(t, plain, nsmerrors.KeepStatus(plain))
+	require.Equal(t, codes.Unknown, nsmerrors.Code(plain))
+	require.Equal(t, codes.OK, nsmerrors.Code(nil))
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package nsmerrors provides a catalogue of the SDK failure modes mapped to gRPC codes. The errors carry
+// errdetails.ErrorInfo with the failure Reason, so the clients can tell the failures apart with ReasonOf.
+package nsmerrors
+
+import (
+	"google.golang.org/grpc/codes"
+)
+
+// Domain is the errdetails.ErrorInfo domain of the SDK errors
+const Domain = "networkservicemesh.io"
+
+// Reason is a machine readable failure reason
+type Reason string
+
+// SDK failure reasons
+const (
+	// ReasonNetworkServiceNotFound - discover has not found the requested Network Service
+	ReasonNetworkServiceNotFound Reason = "NETWORK_SERVICE_NOT_FOUND"
+	// ReasonNoEndpoint - roundrobin has not found an endpoint for the Network Service
+	ReasonNoEndpoint Reason = "NO_ENDPOINT_FOUND"
+	// ReasonInvalidEndpointURL - the selected endpoint has invalid URL
+	ReasonInvalidEndpointURL Reason = "INVALID_ENDPOINT_URL"
+	// ReasonIPAMExhausted - point2pointipam has no free addresses
+	ReasonIPAMExhausted Reason = "IPAM_EXHAUSTED"
+	// ReasonIPAMExcluded - the free point2pointipam addresses are excluded by the request
+	ReasonIPAMExcluded Reason = "IPAM_ADDRESSES_EXCLUDED"
+	// ReasonIPAMInvalidPrefix - the request has invalid excluded prefixes for point2pointipam
+	ReasonIPAMInvalidPrefix Reason = "IPAM_INVALID_PREFIX"
+	// ReasonIPAMInvalidConfig - point2pointipam is configured with no prefixes or invalid ones
+	ReasonIPAMInvalidConfig Reason = "IPAM_INVALID_CONFIG"
+	// ReasonUnsupportedMechanism - mechanisms does not support any of the requested mechanisms
+	ReasonUnsupportedMechanism Reason = "UNSUPPORTED_MECHANISM"
+	// ReasonNoClientURL - connect does not know the URL to connect to
+	ReasonNoClientURL Reason = "NO_CLIENT_URL"
+	// ReasonDialFailed - connect has failed to dial the next hop
+	ReasonDialFailed Reason = "DIAL_FAILED"
+	// ReasonDialTimeout - connect has timed out waiting for the next hop connection
+	ReasonDialTimeout Reason = "DIAL_TIMEOUT"
+	// ReasonInvalidExpiration - timeout has failed to read the path segment expiration time
+	ReasonInvalidExpiration Reason = "INVALID_EXPIRATION"
+)
+
+var reasonCodes = map[Reason]codes.Code{
+	ReasonNetworkServiceNotFound: codes.NotFound,
+	ReasonNoEndpoint:             codes.NotFound,
+	ReasonInvalidEndpointURL:     codes.FailedPrecondition,
+	ReasonIPAMExhausted:          codes.ResourceExhausted,
+	ReasonIPAMExcluded:           codes.ResourceExhausted,
+	ReasonIPAMInvalidPrefix:      codes.InvalidArgument,
+	ReasonIPAMInvalidConfig:      codes.FailedPrecondition,
+	ReasonUnsupportedMechanism:   codes.Unimplemented,
+	ReasonNoClientURL:            codes.FailedPrecondition,
+	ReasonDialFailed:             codes.Unavailable,
+	ReasonDialTimeout:            codes.DeadlineExceeded,
+	ReasonInvalidExpiration:      codes.InvalidArgument,
+}
+
+// Code returns gRPC code of the reason, codes.Unknown for unknown reasons
+func (r Reason) Code() codes.Code {
+	if code, ok := reasonCodes[r]; ok {
+		return code
+	}
+	return codes.Unknown
+}
@@ -0,0 +1,137 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nsmerrors
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type grpcStatus interface {
+	GRPCStatus() *status.Status
+}
+
+// FromError returns the status of the first error in err chain having one (see github.com/pkg/errors Cause) with
+// the message of err, false if there is no such error
+func FromError(err error) (*status.Status, bool) {
+	for cause := err; cause != nil; cause = unwrap(cause) {
+		if se, ok := cause.(grpcStatus); ok {
+			s := se.GRPCStatus()
+			if s == nil {
+				return nil, false
+			}
+			if cause == err {
+				return s, true
+			}
+			p := s.Proto()
+			p.Message = err.Error()
+			return status.FromProto(p), true
+		}
+	}
+	return nil, false
+}
+
+// Code returns gRPC code of err: codes.OK for nil, the status code if err chain has a status, otherwise codes.Unknown
+func Code(err error) codes.Code {
+	if err == nil {
+		return codes.OK
+	}
+	if s, ok := FromError(err); ok {
+		return s.Code()
+	}
+	return codes.Unknown
+}
+
+// ReasonOf returns the SDK failure reason of err, works both for Error and for the status received over gRPC
+func ReasonOf(err error) (Reason, bool) {
+	s, ok := FromError(err)
+	if !ok {
+		return "", false
+	}
+	for _, detail := range s.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok && info.GetDomain() == Domain {
+			return Reason(info.GetReason()), true
+		}
+	}
+	return "", false
+}
+
+// KeepStatus returns err exposing the status of its chain to gRPC, so the wrapped errors keep their codes.
+// If err has no status in its chain or has its own one, err is returned as is.
+func KeepStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := err.(grpcStatus); ok {
+		return err
+	}
+	if _, ok := FromError(err); !ok {
+		return err
+	}
+	return &statusError{err: err}
+}
+
+// statusError is an error with the status of its chain
+type statusError struct {
+	err error
+}
+
+func (e *statusError) Error() string {
+	return e.err.Error()
+}
+
+func (e *statusError) Cause() error {
+	return e.err
+}
+
+func (e *statusError) Unwrap() error {
+	return e.err
+}
+
+func (e *statusError) StackTrace() errors.StackTrace {
+	if st, ok := e.err.(stackTracer); ok {
+		return st.StackTrace()
+	}
+	return nil
+}
+
+func (e *statusError) Format(s fmt.State, verb rune) {
+	if f, ok := e.err.(fmt.Formatter); ok {
+		f.Format(s, verb)
+		return
+	}
+	_, _ = fmt.Fprint(s, e.err.Error())
+}
+
+func (e *statusError) GRPCStatus() *status.Status {
+	s, _ := FromError(e.err)
+	return s
+}
+
+func unwrap(err error) error {
+	switch e := err.(type) {
+	case interface{ Cause() error }:
+		return e.Cause()
+	case interface{ Unwrap() error }:
+		return e.Unwrap()
+	}
+	return nil
+}
@@ -0,0 +1,21 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package nspolicy provides per network service authorization policies distributed by the registry. The policy is
+// carried by the payload of the separate policy network service named by PolicyName, which the registry authorize
+// server treats as owned by the owner of the network service, so only the network service owner controls who can connect
+// to the service, without redeploying the nsmgrs.
+package nspolicy
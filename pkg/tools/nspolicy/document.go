@@ -0,0 +1,90 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nspolicy
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/pkg/errors"
+)
+
+// Suffix is appended to the network service name to get the name of its policy network service
+const Suffix = "@authorization"
+
+// Document is JSON representation of the network service policy. The connection is allowed if all the set checks pass.
+type Document struct {
+	// AllowedSpiffeIDs are SPIFFE IDs of the clients allowed to connect, any client is allowed if empty
+	AllowedSpiffeIDs []string `json:"allowed_spiffe_ids,omitempty"`
+	// Rego is rego module evaluated with opa.ConnectionInput, skipped if empty. The module can't use
+	// opa.UnsafeBuiltins.
+	Rego string `json:"rego,omitempty"`
+	// Query is the rego query which should be true, "allow" if empty
+	Query string `json:"query,omitempty"`
+}
+
+// PolicyName returns name of the policy network service for the network service with name
+func PolicyName(name string) string {
+	return name + Suffix
+}
+
+// NetworkServiceName returns name of the network service the policy network service with name belongs to, false if
+// name is not a policy network service name
+func NetworkServiceName(name string) (string, bool) {
+	if !strings.HasSuffix(name, Suffix) {
+		return "", false
+	}
+	return strings.TrimSuffix(name, Suffix), true
+}
+
+// Publish registers doc as the policy of the network service with name
+func Publish(ctx context.Context, client registry.NetworkServiceRegistryClient, name string, doc *Document) error {
+	payload, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	_, err = client.Register(ctx, &registry.NetworkService{
+		Name:    PolicyName(name),
+		Payload: string(payload),
+	})
+	return err
+}
+
+// Find returns the policy of the network service with name, nil if the network service has no policy
+func Find(ctx context.Context, client registry.NetworkServiceRegistryClient, name string) (*Document, error) {
+	stream, err := client.Find(ctx, &registry.NetworkServiceQuery{
+		NetworkService: &registry.NetworkService{
+			Name: PolicyName(name),
+		},
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	for _, ns := range registry.ReadNetworkServiceList(stream) {
+		if ns.GetName() != PolicyName(name) {
+			continue
+		}
+		doc := new(Document)
+		if err := json.Unmarshal([]byte(ns.GetPayload()), doc); err != nil {
+			return nil, errors.Wrapf(err, "invalid policy of network service %v", name)
+		}
+		return doc, nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,114 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nspolicy
+
+import (
+	"context"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/opa"
+)
+
+// DefaultCacheSize is the default max number of compiled policies kept by Cache
+const DefaultCacheSize = 256
+
+// Cache compiles policy documents to opa.AuthorizationPolicy, compiled policies are reused while the document is not
+// changed. At most size compiled policies are kept, an arbitrary one is evicted when the cache is full.
+type Cache struct {
+	size     int
+	mutex    sync.Mutex
+	policies map[regoKey]opa.AuthorizationPolicy
+}
+
+type regoKey struct {
+	rego, query string
+}
+
+// NewCache creates an empty Cache keeping at most size compiled policies, DefaultCacheSize if size is not positive
+func NewCache(size int) *Cache {
+	if size <= 0 {
+		size = DefaultCacheSize
+	}
+	return &Cache{
+		size:     size,
+		policies: make(map[regoKey]opa.AuthorizationPolicy),
+	}
+}
+
+// Policy returns opa.AuthorizationPolicy checking doc, the policy expects *opa.ConnectionInput input with SpiffeID of
+// the client which has originated the connection
+func (c *Cache) Policy(doc *Document) opa.AuthorizationPolicy {
+	key := regoKey{rego: doc.Rego, query: doc.Query}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	rego, ok := c.policies[key]
+	if !ok && doc.Rego != "" {
+		query := doc.Query
+		if query == "" {
+			query = "allow"
+		}
+		rego = opa.WithUntrustedPolicyFromSource(doc.Rego, query, opa.True)
+		for k := range c.policies {
+			if len(c.policies) < c.size {
+				break
+			}
+			delete(c.policies, k)
+		}
+		c.policies[key] = rego
+	}
+	return &policy{
+		allowed: doc.AllowedSpiffeIDs,
+		rego:    rego,
+	}
+}
+
+type policy struct {
+	allowed []string
+	rego    opa.AuthorizationPolicy
+}
+
+func (p *policy) Name() string {
+	return "network_service"
+}
+
+func (p *policy) Check(ctx context.Context, input interface{}) error {
+	connInput, ok := input.(*opa.ConnectionInput)
+	if !ok {
+		return status.Errorf(codes.Internal, "unexpected input %T", input)
+	}
+	if len(p.allowed) > 0 && !contains(p.allowed, connInput.SpiffeID) {
+		return status.Errorf(codes.PermissionDenied, "%q is not allowed to connect to %v", connInput.SpiffeID, connInput.NetworkService)
+	}
+	if p.rego != nil {
+		return p.rego.Check(ctx, input)
+	}
+	return nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
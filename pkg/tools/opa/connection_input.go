@@ -0,0 +1,82 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opa
+
+import (
+	"context"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/peerid"
+)
+
+// ConnectionInputVersion is the version of ConnectionInput document, it is increased on incompatible changes
+const ConnectionInputVersion = 1
+
+// Operation is the networkservice operation being authorized
+type Operation string
+
+const (
+	// OperationRequest is NetworkService.Request
+	OperationRequest Operation = "request"
+	// OperationClose is NetworkService.Close
+	OperationClose Operation = "close"
+	// OperationResponse is the connection returned to the client by NetworkService.Request
+	OperationResponse Operation = "response"
+)
+
+// ConnectionInput is OPA input document for authorization of networkservice connections.
+// Index and path segments of the connection path are kept in the root of the document, so policies written for the
+// path input keep working.
+type ConnectionInput struct {
+	// Version is ConnectionInputVersion
+	Version int `json:"version"`
+	// Operation is the authorized operation: "request", "close" or "response"
+	Operation Operation `json:"operation"`
+	// SpiffeID is SPIFFE ID of the peer, empty if the peer has no SVID
+	SpiffeID string `json:"spiffe_id"`
+	// NetworkService is the name of the requested network service
+	NetworkService string `json:"network_service"`
+	// Labels are the connection labels
+	Labels map[string]string `json:"labels"`
+	// MechanismType is the type of the connection mechanism, empty if it is not selected yet
+	MechanismType string `json:"mechanism_type"`
+	// Index is the index of the current path segment
+	Index uint32 `json:"index"`
+	// PathSegments are the connection path segments
+	PathSegments []*networkservice.PathSegment `json:"path_segments"`
+	// Connection is the whole connection
+	Connection *networkservice.Connection `json:"connection"`
+}
+
+// NewConnectionInput creates ConnectionInput for the operation with conn, the peer SPIFFE ID is taken from ctx
+func NewConnectionInput(ctx context.Context, operation Operation, conn *networkservice.Connection) *ConnectionInput {
+	input := &ConnectionInput{
+		Version:        ConnectionInputVersion,
+		Operation:      operation,
+		NetworkService: conn.GetNetworkService(),
+		Labels:         conn.GetLabels(),
+		MechanismType:  conn.GetMechanism().GetType(),
+		Index:          conn.GetPath().GetIndex(),
+		PathSegments:   conn.GetPath().GetPathSegments(),
+		Connection:     conn,
+	}
+	if id, err := peerid.FromContext(ctx); err == nil {
+		input.SpiffeID = id.String()
+	}
+	return input
+}
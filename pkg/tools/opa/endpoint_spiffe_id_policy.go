@@ -0,0 +1,50 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opa
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// #nosec
+const endpointSpiffeIDPolicy = `
+package policies
+
+default endpoint_spiffe_id = false
+
+endpoint_spiffe_id {
+	index := count(input.path_segments) - 1
+	token := input.path_segments[index].token
+	token_signed(token, input.trust_bundle.certificates[index])
+	[_, payload, _] := io.jwt.decode(token)
+	payload.sub == %v
+}
+`
+
+// WithEndpointSpiffeIDPolicy returns policy for checking that the last token in path is issued and signed by the
+// endpoint with spiffeID, it is intended for checking connections returned to the client. The token signature is
+// verified by the trust bundle, so context passed to Check should be created with WithTrustBundle, otherwise the
+// check fails.
+func WithEndpointSpiffeIDPolicy(spiffeID string) AuthorizationPolicy {
+	quoted, _ := json.Marshal(spiffeID)
+	return &authorizationPolicy{
+		policySource: fmt.Sprintf(endpointSpiffeIDPolicy, string(quoted)) + tokenSignedRules,
+		query:        "endpoint_spiffe_id",
+		checker:      True("endpoint_spiffe_id"),
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opa_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"testing"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/spiffe/go-spiffe/v2/bundle/x509bundle"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/opa"
+	"github.com/networkservicemesh/sdk/pkg/tools/spiffejwt"
+)
+
+func TestWithEndpointSpiffeIDPolicy(t *testing.T) {
+	ca, err := generateCA()
+	require.Nil(t, err)
+	caCert, err := x509.ParseCertificate(ca.Certificate[0])
+	require.Nil(t, err)
+	id, err := spiffeid.FromString(spiffeID)
+	require.Nil(t, err)
+	ctx := opa.WithTrustBundle(context.Background(), x509bundle.FromX509Authorities(id.TrustDomain(), []*x509.Certificate{caCert}))
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.Nil(t, err)
+	svid := generateSVID(t, &ca, key)
+	endpointToken, _, err := spiffejwt.TokenGeneratorFunc(svid, time.Hour)(nil)
+	require.Nil(t, err)
+
+	forgedKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.Nil(t, err)
+	forgedToken, _, err := spiffejwt.TokenGeneratorFunc(&x509svid.SVID{
+		ID:           svid.ID,
+		Certificates: svid.Certificates,
+		PrivateKey:   forgedKey,
+	}, time.Hour)(nil)
+	require.Nil(t, err)
+
+	clientToken := genJWTWithClaims(&jwt.StandardClaims{Subject: "spiffe://test.com/nsc"})
+
+	p := opa.WithEndpointSpiffeIDPolicy(spiffeID)
+	require.Nil(t, p.Check(ctx, genConnectionWithTokens([]string{clientToken, endpointToken}).GetPath()))
+	require.NotNil(t, p.Check(ctx, genConnectionWithTokens([]string{clientToken, forgedToken}).GetPath()))
+	require.NotNil(t, p.Check(ctx, genConnectionWithTokens([]string{endpointToken, clientToken}).GetPath()))
+	// The unsigned token with the expected subject is rejected
+	require.NotNil(t, p.Check(ctx, genConnectionWithTokens([]string{
+		clientToken,
+		genJWTWithClaims(&jwt.StandardClaims{Subject: spiffeID}),
+	}).GetPath()))
+	// The signature can't be verified without the trust bundle
+	require.NotNil(t, p.Check(context.Background(), genConnectionWithTokens([]string{clientToken, endpointToken}).GetPath()))
+
+	p = opa.WithEndpointSpiffeIDPolicy("spiffe://test.com/another")
+	require.NotNil(t, p.Check(ctx, genConnectionWithTokens([]string{clientToken, endpointToken}).GetPath()))
+
+	require.NotNil(t, p.Check(ctx, genConnectionWithTokens(nil).GetPath()))
+}
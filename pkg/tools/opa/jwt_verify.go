@@ -0,0 +1,125 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opa
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"strings"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/open-policy-agent/opa/ast"
+	"github.com/open-policy-agent/opa/topdown"
+	"github.com/open-policy-agent/opa/topdown/builtins"
+	"github.com/open-policy-agent/opa/types"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/spiffejwt"
+)
+
+// tokenSignedRules is the rego rule checking that the token is signed by the key of the PEM encoded certificate, the
+// verification is chosen by the "alg" token header, so all the signing methods of spiffejwt are supported. Empty
+// certificate (e.g. not verified by the trust bundle) fails the check.
+const tokenSignedRules = `
+token_signed(token, cert) {
+	cert != ""
+	[header, _, _] := io.jwt.decode(token)
+	token_verified(header.alg, token, cert)
+}
+
+token_verified("ES256", token, cert) {
+	io.jwt.verify_es256(token, cert)
+}
+
+token_verified("ES384", token, cert) {
+	io.jwt.verify_es384(token, cert)
+}
+
+token_verified("ES512", token, cert) {
+	io.jwt.verify_es512(token, cert)
+}
+
+token_verified("RS256", token, cert) {
+	io.jwt.verify_rs256(token, cert)
+}
+
+token_verified("EdDSA", token, cert) {
+	io.jwt.verify_eddsa(token, cert)
+}
+`
+
+// jwtVerifyBuiltins are the JWT verification built-in functions missing in OPA, they have the same signature as
+// io.jwt.verify_es256: the token and the PEM encoded certificate or public key
+var jwtVerifyBuiltins = map[string]jwt.SigningMethod{
+	"io.jwt.verify_es384": jwt.SigningMethodES384,
+	"io.jwt.verify_es512": jwt.SigningMethodES512,
+	"io.jwt.verify_eddsa": spiffejwt.SigningMethodEdDSA,
+}
+
+func init() {
+	for name, method := range jwtVerifyBuiltins {
+		if _, ok := ast.BuiltinMap[name]; ok {
+			continue
+		}
+		ast.RegisterBuiltin(&ast.Builtin{
+			Name: name,
+			Decl: types.NewFunction(types.Args(types.S, types.S), types.B),
+		})
+		topdown.RegisterFunctionalBuiltin2(name, jwtVerify(method))
+	}
+}
+
+func jwtVerify(method jwt.SigningMethod) topdown.FunctionalBuiltin2 {
+	return func(a, b ast.Value) (ast.Value, error) {
+		token, err := builtins.StringOperand(a, 1)
+		if err != nil {
+			return nil, err
+		}
+		certificate, err := builtins.StringOperand(b, 2)
+		if err != nil {
+			return nil, err
+		}
+		key, err := publicKey(string(certificate))
+		if err != nil {
+			return nil, err
+		}
+		parts := strings.Split(string(token), ".")
+		if len(parts) != 3 {
+			return nil, errors.New("token contains an invalid number of segments")
+		}
+		return ast.Boolean(method.Verify(strings.Join(parts[:2], "."), parts[2], key) == nil), nil
+	}
+}
+
+// publicKey returns the public key from the PEM encoded certificate or public key
+func publicKey(data string) (interface{}, error) {
+	block, _ := pem.Decode([]byte(data))
+	if block == nil {
+		return nil, errors.New("failed to find a PEM block")
+	}
+	switch block.Type {
+	case "CERTIFICATE":
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		return cert.PublicKey, nil
+	case "PUBLIC KEY":
+		return x509.ParsePKIXPublicKey(block.Bytes)
+	}
+	return nil, errors.Errorf("unsupported PEM block %v", block.Type)
+}
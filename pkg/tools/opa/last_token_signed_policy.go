@@ -28,11 +28,12 @@ index = input.index
 last_token_signed {	
 	token := input.path_segments[index].token	
 	cert := input.auth_info.certificate	
-	io.jwt.verify_es256(token, cert) = true
+	token_signed(token, cert)
 }
-`
+` + tokenSignedRules
 
-// WithLastTokenSignedPolicy returns default policy for checking that last token in path is signed.
+// WithLastTokenSignedPolicy returns default policy for checking that last token in path is signed by the peer
+// certificate. ES256, ES384, ES512, RS256 and EdDSA signatures are supported.
 func WithLastTokenSignedPolicy() AuthorizationPolicy {
 	return &authorizationPolicy{
 		policySource: lastTokenSignedPolicy,
@@ -26,10 +26,11 @@ import (
 
 	"google.golang.org/grpc/peer"
 
-	"google.golang.org/grpc/credentials"
+	"github.com/networkservicemesh/sdk/pkg/tools/peerid"
 )
 
 // PreparedOpaInput - converts model to map. It also puts auth_info in root of the map if it is presented in context.
+// If model is a path and context carries a trust bundle (see WithTrustBundle), trust_bundle is put in root of the map.
 func PreparedOpaInput(ctx context.Context, model interface{}) (map[string]interface{}, error) {
 	result, err := convertToMap(model)
 	if err != nil {
@@ -38,7 +39,7 @@ func PreparedOpaInput(ctx context.Context, model interface{}) (map[string]interf
 	p, ok := peer.FromContext(ctx)
 	var cert *x509.Certificate
 	if ok {
-		cert = parseX509Cert(p.AuthInfo)
+		cert = peerid.Certificate(p.AuthInfo)
 	}
 	var pemcert string
 	if cert != nil {
@@ -47,6 +48,11 @@ func PreparedOpaInput(ctx context.Context, model interface{}) (map[string]interf
 	result["auth_info"] = map[string]interface{}{
 		"certificate": pemcert,
 	}
+	if source, path := trustBundle(ctx), pathOf(model); source != nil && path != nil {
+		result["trust_bundle"] = map[string]interface{}{
+			"certificates": tokenCertificates(source, path),
+		}
+	}
 	return result, nil
 }
 
@@ -55,28 +61,6 @@ func pemEncodingX509Cert(cert *x509.Certificate) string {
 	return string(certpem)
 }
 
-func parseX509Cert(authInfo credentials.AuthInfo) *x509.Certificate {
-	var peerCert *x509.Certificate
-
-	switch v := authInfo.(type) {
-	case *credentials.TLSInfo:
-		if len(v.State.PeerCertificates) > 0 {
-			peerCert = v.State.PeerCertificates[0]
-		}
-	case credentials.TLSInfo:
-		if len(v.State.PeerCertificates) > 0 {
-			peerCert = v.State.PeerCertificates[0]
-		}
-	}
-
-	if tlsInfo, ok := authInfo.(*credentials.TLSInfo); ok {
-		if len(tlsInfo.State.PeerCertificates) > 0 {
-			peerCert = tlsInfo.State.PeerCertificates[0]
-		}
-	}
-	return peerCert
-}
-
 func convertToMap(model interface{}) (map[string]interface{}, error) {
 	jsonConn, err := json.Marshal(model)
 	if err != nil {
@@ -18,6 +18,7 @@ package opa
 
 import (
 	"context"
+	"fmt"
 	"io/ioutil"
 	"path/filepath"
 	"strings"
@@ -41,6 +42,15 @@ type AuthorizationPolicy interface {
 	Check(ctx context.Context, input interface{}) error
 }
 
+// PolicyName returns name of the policy. Policies created by this package are named by their query, other policies
+// may provide a name by implementing Name() string method, otherwise the policy type name is used
+func PolicyName(p AuthorizationPolicy) string {
+	if named, ok := p.(interface{ Name() string }); ok {
+		return named.Name()
+	}
+	return fmt.Sprintf("%T", p)
+}
+
 // True is default access checker, returns true if in the result set of rego exist query and it has true value
 func True(query string) CheckAccessFunc {
 	return func(rs rego.ResultSet) (bool, error) {
@@ -80,6 +90,25 @@ func WithPolicyFromSource(source, query string, checkQuery CheckQueryFunc) Autho
 	}
 }
 
+// UnsafeBuiltins are the rego built-in functions reaching the network or the runtime, or expensive enough to block the
+// evaluation, which are not allowed in the policies from the untrusted sources
+var UnsafeBuiltins = []string{"http.send", "opa.runtime", "rego.parse_module", "net.cidr_expand", "trace"}
+
+// WithUntrustedPolicyFromSource creates custom policy based on rego source code coming from an untrusted source, the
+// policy using any of UnsafeBuiltins fails to compile
+func WithUntrustedPolicyFromSource(source, query string, checkQuery CheckQueryFunc) AuthorizationPolicy {
+	unsafeBuiltins := make(map[string]struct{}, len(UnsafeBuiltins))
+	for _, name := range UnsafeBuiltins {
+		unsafeBuiltins[name] = struct{}{}
+	}
+	return &authorizationPolicy{
+		policySource:   strings.TrimSpace(source),
+		query:          query,
+		checker:        checkQuery(query),
+		unsafeBuiltins: unsafeBuiltins,
+	}
+}
+
 // WithPolicyFromFile creates custom policy based on rego source file
 func WithPolicyFromFile(path, query string, checkQuery CheckQueryFunc) AuthorizationPolicy {
 	return &authorizationPolicy{
@@ -97,22 +126,34 @@ type authorizationPolicy struct {
 	query          string
 	evalQuery      *rego.PreparedEvalQuery
 	checker        CheckAccessFunc
+	unsafeBuiltins map[string]struct{}
 	once           sync.Once
 }
 
+func (d *authorizationPolicy) Name() string {
+	if d.query == "" {
+		return strings.TrimSuffix(filepath.Base(d.policyFilePath), filepath.Ext(d.policyFilePath))
+	}
+	return d.query
+}
+
 func (d *authorizationPolicy) Check(ctx context.Context, model interface{}) error {
+	if intErr := d.init(); intErr != nil {
+		return intErr
+	}
+	return check(ctx, d.evalQuery, d.checker, model)
+}
+
+func check(ctx context.Context, evalQuery *rego.PreparedEvalQuery, checker CheckAccessFunc, model interface{}) error {
 	input, err := PreparedOpaInput(ctx, model)
 	if err != nil {
 		return err
 	}
-	if intErr := d.init(); intErr != nil {
-		return intErr
-	}
-	rs, err := d.evalQuery.Eval(ctx, rego.EvalInput(input))
+	rs, err := evalQuery.Eval(ctx, rego.EvalInput(input))
 	if err != nil {
 		return status.Error(codes.Internal, err.Error())
 	}
-	hasAccess, err := d.checker(rs)
+	hasAccess, err := checker(rs)
 	if err != nil {
 		return status.Error(codes.Internal, err.Error())
 	}
@@ -133,10 +174,15 @@ func (d *authorizationPolicy) init() error {
 		if d.initErr = d.checkModule(); d.initErr != nil {
 			return
 		}
-		var r rego.PreparedEvalQuery
-		r, d.initErr = rego.New(
+		options := []func(*rego.Rego){
 			rego.Query(strings.Join([]string{"data", d.pkg, d.query}, ".")),
-			rego.Module(d.pkg, d.policySource)).PrepareForEval(context.Background())
+			rego.Module(d.pkg, d.policySource),
+		}
+		if d.unsafeBuiltins != nil {
+			options = append(options, rego.UnsafeBuiltins(d.unsafeBuiltins))
+		}
+		var r rego.PreparedEvalQuery
+		r, d.initErr = rego.New(options...).PrepareForEval(context.Background())
 		if d.initErr != nil {
 			return
 		}
@@ -168,15 +214,20 @@ func (d *authorizationPolicy) checkModule() error {
 	if d.pkg != "" {
 		return nil
 	}
+	var err error
+	d.pkg, err = packageOf(d.policySource)
+	return err
+}
+
+func packageOf(source string) (string, error) {
 	const pkg = "package"
-	lines := strings.Split(d.policySource, "\n")
+	lines := strings.Split(source, "\n")
 	for i := 0; i < len(lines); i++ {
 		if strings.HasPrefix(lines[i], pkg) {
-			d.pkg = strings.TrimSpace(lines[i][len(pkg):])
-			return nil
+			return strings.TrimSpace(lines[i][len(pkg):]), nil
 		}
 	}
-	return errors.New("missed package")
+	return "", errors.New("missed package")
 }
 
 var _ AuthorizationPolicy = &authorizationPolicy{}
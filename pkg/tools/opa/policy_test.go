@@ -53,3 +53,23 @@ func TestWithPolicyFromFile(t *testing.T) {
 	err = p.Check(context.Background(), nil)
 	require.Nil(t, err)
 }
+
+func TestWithUntrustedPolicyFromSource(t *testing.T) {
+	p := opa.WithUntrustedPolicyFromSource(`
+		package test
+
+		default allow = true
+	`, "allow", opa.True)
+	require.Nil(t, p.Check(context.Background(), nil))
+
+	p = opa.WithUntrustedPolicyFromSource(`
+		package test
+
+		allow {
+			http.send({"method": "get", "url": "http://127.0.0.1:1"})
+		}
+	`, "allow", opa.True)
+	err := p.Check(context.Background(), nil)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "http.send")
+}
@@ -0,0 +1,44 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opa
+
+// #nosec
+const registrationOwnerPolicy = `
+package registry
+
+default registration_owner = false
+
+registration_owner {
+	input.spiffe_id != ""
+	input.owner == ""
+}
+
+registration_owner {
+	input.spiffe_id != ""
+	input.owner == input.spiffe_id
+}
+`
+
+// WithRegistrationOwnerPolicy returns default policy for checking that registry resource is changed only by its owner.
+// Input is expected to contain "spiffe_id" of the caller and "owner" SPIFFE ID, which is empty for not owned resources.
+func WithRegistrationOwnerPolicy() AuthorizationPolicy {
+	return &authorizationPolicy{
+		policySource: registrationOwnerPolicy,
+		query:        "registration_owner",
+		checker:      True("registration_owner"),
+	}
+}
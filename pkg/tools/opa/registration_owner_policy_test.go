@@ -0,0 +1,42 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opa_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/opa"
+)
+
+func TestWithRegistrationOwnerPolicy(t *testing.T) {
+	p := opa.WithRegistrationOwnerPolicy()
+
+	input := func(spiffeID, owner string) map[string]interface{} {
+		return map[string]interface{}{
+			"spiffe_id": spiffeID,
+			"owner":     owner,
+		}
+	}
+
+	require.Nil(t, p.Check(context.Background(), input(spiffeID, "")))
+	require.Nil(t, p.Check(context.Background(), input(spiffeID, spiffeID)))
+	require.NotNil(t, p.Check(context.Background(), input(spiffeID, "spiffe://test.com/another")))
+	require.NotNil(t, p.Check(context.Background(), input("", "")))
+}
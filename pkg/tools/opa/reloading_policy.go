@@ -0,0 +1,244 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opa
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/open-policy-agent/opa/rego"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+type compiledPolicy struct {
+	evalQuery *rego.PreparedEvalQuery
+	sources   map[string]string
+}
+
+// ReloadingPolicy is the policy reloaded on the changes of its source files
+type ReloadingPolicy struct {
+	path    string
+	query   string
+	checker CheckAccessFunc
+	// current holds the last successfully compiled *compiledPolicy
+	current atomic.Value
+	// lastErr is the error of the last failed load, it is reset on success
+	lastErr      error
+	lastErrMutex sync.Mutex
+}
+
+// WithReloadingPolicyFromFile creates custom policy based on rego source file or directory, which is reloaded on the
+// file system events in the directory of the file (or in the directory itself) and additionally re-read every period
+// in case an event is missed. Directory policy consists of all *.rego files in the directory, they should have the
+// same package. Changed sources are recompiled and replace the policy atomically, if compilation fails the last good
+// policy is kept. Until the policy is compiled for the first time Check fails with Unavailable. Reloads and errors
+// are logged. Watching stops when ctx is done.
+func WithReloadingPolicyFromFile(ctx context.Context, period time.Duration, path, query string, checkQuery CheckQueryFunc) *ReloadingPolicy {
+	if query == "" {
+		query = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	p := &ReloadingPolicy{
+		path:    path,
+		query:   query,
+		checker: checkQuery(query),
+	}
+	// the watcher is added before the first load, so no change after it is missed
+	watcher, err := p.newWatcher()
+	if err != nil {
+		log.Entry(ctx).WithField("policy", p.path).Warnf("policy changes are only polled: %v", err)
+	}
+	p.reload(ctx)
+	go p.watch(ctx, period, watcher)
+	return p
+}
+
+// Name returns the policy query
+func (p *ReloadingPolicy) Name() string {
+	return p.query
+}
+
+// Check checks the model by the last successfully compiled policy
+func (p *ReloadingPolicy) Check(ctx context.Context, model interface{}) error {
+	compiled, ok := p.current.Load().(*compiledPolicy)
+	if !ok {
+		return status.Errorf(codes.Unavailable, "policy %v is not loaded: %v", p.path, p.LastError())
+	}
+	return check(ctx, compiled.evalQuery, p.checker, model)
+}
+
+// LastError returns the error of the last failed load, nil if the last load has succeeded
+func (p *ReloadingPolicy) LastError() error {
+	p.lastErrMutex.Lock()
+	defer p.lastErrMutex.Unlock()
+	return p.lastErr
+}
+
+func (p *ReloadingPolicy) watch(ctx context.Context, period time.Duration, watcher *fsnotify.Watcher) {
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+	var events <-chan fsnotify.Event
+	var watchErrors <-chan error
+	if watcher != nil {
+		defer func() { _ = watcher.Close() }()
+		events, watchErrors = watcher.Events, watcher.Errors
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			p.reload(ctx)
+		case <-events:
+			p.reload(ctx)
+		case err := <-watchErrors:
+			log.Entry(ctx).WithField("policy", p.path).Warnf("policy watch error: %v", err)
+		}
+	}
+}
+
+// newWatcher watches the directory of the policy file or the policy directory itself, so the files replaced by rename
+// (e.g. by editors or Kubernetes ConfigMap updates) are noticed too
+func (p *ReloadingPolicy) newWatcher() (*fsnotify.Watcher, error) {
+	dir := filepath.Dir(p.path)
+	if info, err := os.Stat(p.path); err == nil && info.IsDir() {
+		dir = p.path
+	}
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	if err := watcher.Add(dir); err != nil {
+		_ = watcher.Close()
+		return nil, err
+	}
+	return watcher, nil
+}
+
+func (p *ReloadingPolicy) reload(ctx context.Context) {
+	logEntry := log.Entry(ctx).WithField("policy", p.path)
+	sources, err := readSources(p.path)
+	if err != nil {
+		p.setError(ctx, err)
+		return
+	}
+	if compiled, ok := p.current.Load().(*compiledPolicy); ok && equalSources(compiled.sources, sources) {
+		return
+	}
+	compiled, err := compile(ctx, sources, p.query)
+	if err != nil {
+		p.setError(ctx, err)
+		return
+	}
+	p.current.Store(compiled)
+	p.lastErrMutex.Lock()
+	p.lastErr = nil
+	p.lastErrMutex.Unlock()
+	logEntry.Infof("policy is loaded")
+}
+
+func (p *ReloadingPolicy) setError(ctx context.Context, err error) {
+	p.lastErrMutex.Lock()
+	defer p.lastErrMutex.Unlock()
+	// the same error is logged only once
+	if p.lastErr != nil && p.lastErr.Error() == err.Error() {
+		return
+	}
+	p.lastErr = err
+	if _, ok := p.current.Load().(*compiledPolicy); ok {
+		log.Entry(ctx).WithField("policy", p.path).Errorf("policy is not reloaded, the last good one is kept: %v", err)
+		return
+	}
+	log.Entry(ctx).WithField("policy", p.path).Errorf("policy is not loaded: %v", err)
+}
+
+func readSources(path string) (map[string]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	files := []string{path}
+	if info.IsDir() {
+		if files, err = filepath.Glob(filepath.Join(path, "*.rego")); err != nil {
+			return nil, err
+		}
+		sort.Strings(files)
+	}
+	sources := make(map[string]string, len(files))
+	for _, file := range files {
+		b, err := ioutil.ReadFile(filepath.Clean(file))
+		if err != nil {
+			return nil, err
+		}
+		sources[file] = string(bytes.TrimSpace(b))
+	}
+	return sources, nil
+}
+
+func equalSources(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if bv, ok := b[k]; !ok || bv != v {
+			return false
+		}
+	}
+	return true
+}
+
+func compile(ctx context.Context, sources map[string]string, query string) (*compiledPolicy, error) {
+	if len(sources) == 0 {
+		return nil, errors.New("no policy sources")
+	}
+	var pkg string
+	options := []func(*rego.Rego){}
+	for file, source := range sources {
+		sourcePkg, err := packageOf(source)
+		if err != nil {
+			return nil, errors.Wrap(err, file)
+		}
+		if pkg != "" && sourcePkg != pkg {
+			return nil, errors.Errorf("%v: package %v differs from %v", file, sourcePkg, pkg)
+		}
+		pkg = sourcePkg
+		options = append(options, rego.Module(file, source))
+	}
+	options = append(options, rego.Query(strings.Join([]string{"data", pkg, query}, ".")))
+	r, err := rego.New(options...).PrepareForEval(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &compiledPolicy{
+		evalQuery: &r,
+		sources:   sources,
+	}, nil
+}
+
+var _ AuthorizationPolicy = &ReloadingPolicy{}
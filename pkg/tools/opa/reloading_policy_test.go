@@ -0,0 +1,160 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opa_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/opa"
+)
+
+const (
+	allowPolicy = `
+		package test
+
+		default allow = true
+	`
+	denyPolicy = `
+		package test
+
+		default allow = false
+	`
+	brokenPolicy = `
+		package test
+
+		default allow = 
+	`
+	reloadPeriod = time.Millisecond * 10
+)
+
+func TestWithReloadingPolicyFromFile(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	dir := filepath.Clean(path.Join(os.TempDir(), t.Name()))
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+	require.Nil(t, os.MkdirAll(dir, os.ModePerm))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	policyPath := filepath.Clean(path.Join(dir, "allow.rego"))
+	p := opa.WithReloadingPolicyFromFile(ctx, reloadPeriod, policyPath, "", opa.True)
+	require.Equal(t, codes.Unavailable, status.Code(p.Check(context.Background(), nil)))
+	require.NotNil(t, p.LastError())
+
+	require.Nil(t, ioutil.WriteFile(policyPath, []byte(allowPolicy), os.ModePerm))
+	require.Eventually(t, func() bool {
+		return p.Check(context.Background(), nil) == nil
+	}, time.Second, reloadPeriod)
+
+	require.Nil(t, ioutil.WriteFile(policyPath, []byte(denyPolicy), os.ModePerm))
+	require.Eventually(t, func() bool {
+		return p.Check(context.Background(), nil) != nil
+	}, time.Second, reloadPeriod)
+
+	require.Nil(t, ioutil.WriteFile(policyPath, []byte(brokenPolicy), os.ModePerm))
+	require.Eventually(t, func() bool {
+		return p.LastError() != nil
+	}, time.Second, reloadPeriod)
+	err := p.Check(context.Background(), nil)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "no sufficient privileges")
+
+	require.Nil(t, ioutil.WriteFile(policyPath, []byte(allowPolicy), os.ModePerm))
+	require.Eventually(t, func() bool {
+		return p.Check(context.Background(), nil) == nil
+	}, time.Second, reloadPeriod)
+}
+
+func TestWithReloadingPolicyFromFile_Directory(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	dir := filepath.Clean(path.Join(os.TempDir(), t.Name()))
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+	require.Nil(t, os.MkdirAll(dir, os.ModePerm))
+
+	const rulePolicy = `
+		package test
+
+		default allow = false
+
+		allow {
+			allowed_tokens[input.token]
+		}
+	`
+	writeTokens := func(tokens string) {
+		require.Nil(t, ioutil.WriteFile(filepath.Join(dir, "tokens.rego"), []byte(`
+			package test
+
+			allowed_tokens = {`+tokens+`}
+		`), os.ModePerm))
+	}
+	require.Nil(t, ioutil.WriteFile(filepath.Join(dir, "allow.rego"), []byte(rulePolicy), os.ModePerm))
+	writeTokens(`"a"`)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	p := opa.WithReloadingPolicyFromFile(ctx, reloadPeriod, dir, "allow", opa.True)
+	require.Nil(t, p.Check(context.Background(), map[string]string{"token": "a"}))
+	require.NotNil(t, p.Check(context.Background(), map[string]string{"token": "b"}))
+
+	writeTokens(`"a", "b"`)
+	require.Eventually(t, func() bool {
+		return p.Check(context.Background(), map[string]string{"token": "b"}) == nil
+	}, time.Second, reloadPeriod)
+}
+
+func TestWithReloadingPolicyFromFile_Events(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	dir := filepath.Clean(path.Join(os.TempDir(), t.Name()))
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+	require.Nil(t, os.MkdirAll(dir, os.ModePerm))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	policyPath := filepath.Clean(path.Join(dir, "allow.rego"))
+	require.Nil(t, ioutil.WriteFile(policyPath, []byte(denyPolicy), os.ModePerm))
+	// The period is too long for the test, so the change can be noticed only by the file system event
+	p := opa.WithReloadingPolicyFromFile(ctx, time.Hour, policyPath, "", opa.True)
+	require.NotNil(t, p.Check(context.Background(), nil))
+
+	// Replace the file by rename, like editors and ConfigMap updates do
+	tmpPath := filepath.Clean(path.Join(dir, "allow.rego.tmp"))
+	require.Nil(t, ioutil.WriteFile(tmpPath, []byte(allowPolicy), os.ModePerm))
+	require.Nil(t, os.Rename(tmpPath, policyPath))
+	require.Eventually(t, func() bool {
+		return p.Check(context.Background(), nil) == nil
+	}, time.Second, reloadPeriod)
+	require.Nil(t, p.LastError())
+}
@@ -0,0 +1,47 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opa
+
+// #nosec
+const tokensOutliveRefreshPolicy = `
+package policies
+
+default tokens_outlive_refresh = false
+default index = 0
+
+index = input.index
+
+refresh_time = t {
+	[_, payload, _] := io.jwt.decode(input.path_segments[index].token)
+	t := payload.exp
+}
+
+tokens_outlive_refresh {
+	expiring := {x | input.path_segments[x]; [_, payload, _] := io.jwt.decode(input.path_segments[x].token); payload.exp < refresh_time}
+	count(expiring) == 0
+}
+`
+
+// WithTokensOutliveRefreshPolicy returns policy for checking that no token in path expires before the token of the
+// current path segment, which defines the refresh time of the connection
+func WithTokensOutliveRefreshPolicy() AuthorizationPolicy {
+	return &authorizationPolicy{
+		policySource: tokensOutliveRefreshPolicy,
+		query:        "tokens_outlive_refresh",
+		checker:      True("tokens_outlive_refresh"),
+	}
+}
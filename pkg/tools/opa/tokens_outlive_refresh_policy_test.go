@@ -0,0 +1,46 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opa_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/opa"
+)
+
+func TestWithTokensOutliveRefreshPolicy(t *testing.T) {
+	p := opa.WithTokensOutliveRefreshPolicy()
+
+	conn := genConnectionWithTokens([]string{
+		genJWTWithClaimsWithYear(3000),
+		genJWTWithClaimsWithYear(3001),
+		genJWTWithClaimsWithYear(3000),
+	})
+	require.Nil(t, p.Check(context.Background(), conn.GetPath()))
+
+	conn = genConnectionWithTokens([]string{
+		genJWTWithClaimsWithYear(3000),
+		genJWTWithClaimsWithYear(2999),
+	})
+	require.NotNil(t, p.Check(context.Background(), conn.GetPath()))
+
+	conn.GetPath().Index = 1
+	require.Nil(t, p.Check(context.Background(), conn.GetPath()))
+}
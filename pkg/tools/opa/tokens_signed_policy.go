@@ -0,0 +1,43 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opa
+
+// #nosec
+const tokensSignedPolicy = `
+package policies
+
+default tokens_signed = false
+
+tokens_signed {
+	count(input.path_segments) == 0
+}
+
+tokens_signed {
+	c := count({x | input.path_segments[x]; token_signed(input.path_segments[x].token, input.trust_bundle.certificates[x])})
+	c == count(input.path_segments)
+}
+` + tokenSignedRules
+
+// WithTokensSignedPolicy returns policy for checking that every token in the path is signed by its subject SVID.
+// ES256, ES384, ES512, RS256 and EdDSA signatures are supported, the verification is chosen by the "alg" token header. The input is expected to contain trust_bundle, so context passed to Check should be created with WithTrustBundle.
+func WithTokensSignedPolicy() AuthorizationPolicy {
+	return &authorizationPolicy{
+		policySource: tokensSignedPolicy,
+		query:        "tokens_signed",
+		checker:      True("tokens_signed"),
+	}
+}
@@ -0,0 +1,161 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opa_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"math/big"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/spiffe/go-spiffe/v2/bundle/x509bundle"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/opa"
+	"github.com/networkservicemesh/sdk/pkg/tools/spiffejwt"
+)
+
+func TestWithTokensSignedPolicy(t *testing.T) {
+	ca, err := generateCA()
+	require.Nil(t, err)
+	caCert, err := x509.ParseCertificate(ca.Certificate[0])
+	require.Nil(t, err)
+
+	cert, err := generateKeyPair(spiffeID, "test.com", &ca)
+	require.Nil(t, err)
+	x509crt, err := x509.ParseCertificate(cert.Certificate[0])
+	require.Nil(t, err)
+	id, err := spiffeid.FromString(spiffeID)
+	require.Nil(t, err)
+
+	svid := &x509svid.SVID{
+		ID:           id,
+		Certificates: []*x509.Certificate{x509crt},
+		PrivateKey:   cert.PrivateKey.(*ecdsa.PrivateKey),
+	}
+	validToken, _, err := spiffejwt.TokenGeneratorFunc(svid, time.Hour)(nil)
+	require.Nil(t, err)
+
+	forgedKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.Nil(t, err)
+	forgedToken, _, err := spiffejwt.TokenGeneratorFunc(&x509svid.SVID{
+		ID:           id,
+		Certificates: []*x509.Certificate{x509crt},
+		PrivateKey:   forgedKey,
+	}, time.Hour)(nil)
+	require.Nil(t, err)
+
+	noChainToken, err := jwt.NewWithClaims(jwt.SigningMethodES256, &jwt.StandardClaims{Subject: spiffeID}).SignedString(cert.PrivateKey)
+	require.Nil(t, err)
+
+	bundle := x509bundle.FromX509Authorities(id.TrustDomain(), []*x509.Certificate{caCert})
+	ctx := opa.WithTrustBundle(context.Background(), bundle)
+
+	path := func(tokens ...string) *networkservice.Path {
+		return genConnectionWithTokens(tokens).GetPath()
+	}
+
+	p := opa.WithTokensSignedPolicy()
+	require.Nil(t, p.Check(ctx, path(validToken, validToken)))
+	require.NotNil(t, p.Check(ctx, path(validToken, forgedToken)))
+	require.NotNil(t, p.Check(ctx, path(noChainToken)))
+	require.NotNil(t, p.Check(context.Background(), path(validToken)))
+
+	anotherCA, err := generateCA()
+	require.Nil(t, err)
+	anotherCACert, err := x509.ParseCertificate(anotherCA.Certificate[0])
+	require.Nil(t, err)
+	anotherBundle := x509bundle.FromX509Authorities(id.TrustDomain(), []*x509.Certificate{anotherCACert})
+	require.NotNil(t, p.Check(opa.WithTrustBundle(context.Background(), anotherBundle), path(validToken)))
+}
+
+func generateSVID(t *testing.T, ca *tls.Certificate, key crypto.Signer) *x509svid.SVID {
+	id, err := spiffeid.FromString(spiffeID)
+	require.Nil(t, err)
+	caCert, err := x509.ParseCertificate(ca.Certificate[0])
+	require.Nil(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1659),
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		URIs:         []*url.URL{id.URL()},
+	}
+	certBytes, err := x509.CreateCertificate(rand.Reader, template, caCert, key.Public(), ca.PrivateKey)
+	require.Nil(t, err)
+	cert, err := x509.ParseCertificate(certBytes)
+	require.Nil(t, err)
+	return &x509svid.SVID{
+		ID:           id,
+		Certificates: []*x509.Certificate{cert},
+		PrivateKey:   key,
+	}
+}
+
+func TestWithTokensSignedPolicy_KeyTypes(t *testing.T) {
+	ca, err := generateCA()
+	require.Nil(t, err)
+	caCert, err := x509.ParseCertificate(ca.Certificate[0])
+	require.Nil(t, err)
+	id, err := spiffeid.FromString(spiffeID)
+	require.Nil(t, err)
+	ctx := opa.WithTrustBundle(context.Background(), x509bundle.FromX509Authorities(id.TrustDomain(), []*x509.Certificate{caCert}))
+
+	generateKeys := map[string]func() (crypto.Signer, error){
+		"ES256": func() (crypto.Signer, error) { return ecdsa.GenerateKey(elliptic.P256(), rand.Reader) },
+		"ES384": func() (crypto.Signer, error) { return ecdsa.GenerateKey(elliptic.P384(), rand.Reader) },
+		"ES512": func() (crypto.Signer, error) { return ecdsa.GenerateKey(elliptic.P521(), rand.Reader) },
+		"RS256": func() (crypto.Signer, error) { return rsa.GenerateKey(rand.Reader, 2048) },
+		"EdDSA": func() (crypto.Signer, error) {
+			_, key, err := ed25519.GenerateKey(rand.Reader)
+			return key, err
+		},
+	}
+	p := opa.WithTokensSignedPolicy()
+	for alg, generateKey := range generateKeys {
+		key, err := generateKey()
+		require.Nil(t, err)
+		forgedKey, err := generateKey()
+		require.Nil(t, err)
+		svid := generateSVID(t, &ca, key)
+
+		validToken, _, err := spiffejwt.TokenGeneratorFunc(svid, time.Hour)(nil)
+		require.Nil(t, err)
+		forgedToken, _, err := spiffejwt.TokenGeneratorFunc(&x509svid.SVID{
+			ID:           svid.ID,
+			Certificates: svid.Certificates,
+			PrivateKey:   forgedKey,
+		}, time.Hour)(nil)
+		require.Nil(t, err)
+
+		require.Nil(t, p.Check(ctx, genConnectionWithTokens([]string{validToken}).GetPath()), alg)
+		require.NotNil(t, p.Check(ctx, genConnectionWithTokens([]string{forgedToken}).GetPath()), alg)
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opa
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/base64"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/spiffe/go-spiffe/v2/bundle/x509bundle"
+	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
+)
+
+type trustBundleKey struct{}
+
+// WithTrustBundle returns a new context carrying the SPIFFE trust bundle source. PreparedOpaInput puts into "trust_bundle"
+// the certificates that have signed path tokens if their chains are verified by the trust bundle.
+func WithTrustBundle(ctx context.Context, source x509bundle.Source) context.Context {
+	return context.WithValue(ctx, trustBundleKey{}, source)
+}
+
+func trustBundle(ctx context.Context) x509bundle.Source {
+	if source, ok := ctx.Value(trustBundleKey{}).(x509bundle.Source); ok {
+		return source
+	}
+	return nil
+}
+
+// tokenCertificates returns PEM encoded signer certificates for each token of the path. Signer certificate chain is
+// taken from "x5c" token header, it is returned only if the chain is verified by the source and its SPIFFE ID is
+// equal to the token subject.
+func tokenCertificates(source x509bundle.Source, path *networkservice.Path) []string {
+	result := make([]string, len(path.GetPathSegments()))
+	for i, segment := range path.GetPathSegments() {
+		if cert := verifiedSigner(source, segment.GetToken()); cert != nil {
+			result[i] = pemEncodingX509Cert(cert)
+		}
+	}
+	return result
+}
+
+func verifiedSigner(source x509bundle.Source, token string) *x509.Certificate {
+	claims := new(jwt.StandardClaims)
+	tok, _, err := new(jwt.Parser).ParseUnverified(token, claims)
+	if err != nil {
+		return nil
+	}
+	x5c, ok := tok.Header["x5c"].([]interface{})
+	if !ok || len(x5c) == 0 {
+		return nil
+	}
+	var certs []*x509.Certificate
+	for _, v := range x5c {
+		s, ok := v.(string)
+		if !ok {
+			return nil
+		}
+		der, err := base64.StdEncoding.DecodeString(s)
+		if err != nil {
+			return nil
+		}
+		cert, err := x509.ParseCertificate(der)
+		if err != nil {
+			return nil
+		}
+		certs = append(certs, cert)
+	}
+	id, _, err := x509svid.Verify(certs, source)
+	if err != nil || id.String() != claims.Subject {
+		return nil
+	}
+	return certs[0]
+}
+
+func pathOf(model interface{}) *networkservice.Path {
+	switch v := model.(type) {
+	case *networkservice.Path:
+		return v
+	case networkservice.Path:
+		return &v
+	case *ConnectionInput:
+		return v.Connection.GetPath()
+	}
+	return nil
+}
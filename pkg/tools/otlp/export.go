@@ -0,0 +1,241 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	scopeName = "github.com/networkservicemesh/sdk/pkg/tools/otlp"
+
+	spanKindTag  = "span.kind"
+	errorTag     = "error"
+	errorMessage = "message"
+
+	statusCodeError = 2
+
+	exportTimeout = 10 * time.Second
+)
+
+// OTLP span kinds
+var spanKinds = map[string]int{
+	"server":   2,
+	"client":   3,
+	"producer": 4,
+	"consumer": 5,
+}
+
+const spanKindInternal = 1
+
+// JSON mapping of OTLP ExportTraceServiceRequest, ids are hex encoded and 64-bit integers are strings
+type exportRequest struct {
+	ResourceSpans []resourceSpans `json:"resourceSpans"`
+}
+
+type resourceSpans struct {
+	Resource   resource     `json:"resource"`
+	ScopeSpans []scopeSpans `json:"scopeSpans"`
+}
+
+type resource struct {
+	Attributes []keyValue `json:"attributes"`
+}
+
+type scopeSpans struct {
+	Scope scope      `json:"scope"`
+	Spans []spanJSON `json:"spans"`
+}
+
+type scope struct {
+	Name string `json:"name"`
+}
+
+type spanJSON struct {
+	TraceID           string      `json:"traceId"`
+	SpanID            string      `json:"spanId"`
+	TraceState        string      `json:"traceState,omitempty"`
+	ParentSpanID      string      `json:"parentSpanId,omitempty"`
+	Name              string      `json:"name"`
+	Kind              int         `json:"kind"`
+	StartTimeUnixNano string      `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string      `json:"endTimeUnixNano"`
+	Attributes        []keyValue  `json:"attributes,omitempty"`
+	Events            []eventJSON `json:"events,omitempty"`
+	Status            status      `json:"status"`
+}
+
+type eventJSON struct {
+	TimeUnixNano string     `json:"timeUnixNano"`
+	Name         string     `json:"name"`
+	Attributes   []keyValue `json:"attributes,omitempty"`
+}
+
+type status struct {
+	Code    int    `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+type keyValue struct {
+	Key   string   `json:"key"`
+	Value anyValue `json:"value"`
+}
+
+type anyValue struct {
+	StringValue *string  `json:"stringValue,omitempty"`
+	BoolValue   *bool    `json:"boolValue,omitempty"`
+	IntValue    *string  `json:"intValue,omitempty"`
+	DoubleValue *float64 `json:"doubleValue,omitempty"`
+}
+
+// export sends the finished spans to the collector
+func (t *Tracer) export() error {
+	t.mutex.Lock()
+	batch := t.batch
+	t.batch = nil
+	t.mutex.Unlock()
+
+	if len(batch) == 0 {
+		return nil
+	}
+
+	spans := make([]spanJSON, 0, len(batch))
+	for _, s := range batch {
+		spans = append(spans, s.toJSON())
+	}
+	body, err := json.Marshal(&exportRequest{
+		ResourceSpans: []resourceSpans{{
+			Resource: resource{
+				Attributes: []keyValue{newKeyValue("service.name", t.service)},
+			},
+			ScopeSpans: []scopeSpans{{
+				Scope: scope{Name: scopeName},
+				Spans: spans,
+			}},
+		}},
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal spans")
+	}
+
+	request, err := http.NewRequest(http.MethodPost, t.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrapf(err, "failed to create request to %s", t.endpoint)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), exportTimeout)
+	defer cancel()
+	request = request.WithContext(ctx)
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := t.client.Do(request)
+	if err != nil {
+		return errors.Wrapf(err, "failed to send %d spans", len(spans))
+	}
+	defer func() { _ = response.Body.Close() }()
+	_, _ = io.Copy(ioutil.Discard, response.Body)
+
+	if response.StatusCode/100 != 2 {
+		return errors.Errorf("failed to send %d spans: %s", len(spans), response.Status)
+	}
+	return nil
+}
+
+func (s *span) toJSON() spanJSON {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	rv := spanJSON{
+		TraceID:           s.context.traceID.String(),
+		SpanID:            s.context.spanID.String(),
+		TraceState:        s.context.traceState,
+		Name:              s.name,
+		Kind:              spanKindInternal,
+		StartTimeUnixNano: strconv.FormatInt(s.start.UnixNano(), 10),
+		EndTimeUnixNano:   strconv.FormatInt(s.end.UnixNano(), 10),
+	}
+	if s.parentID.isValid() {
+		rv.ParentSpanID = s.parentID.String()
+	}
+	if kind, ok := spanKinds[fmt.Sprint(s.tags[spanKindTag])]; ok {
+		rv.Kind = kind
+	}
+	rv.Attributes = newKeyValues(s.tags)
+	for _, e := range s.events {
+		rv.Events = append(rv.Events, eventJSON{
+			TimeUnixNano: strconv.FormatInt(e.time.UnixNano(), 10),
+			Name:         e.name,
+			Attributes:   newKeyValues(e.attributes),
+		})
+		if e.name == errorTag && rv.Status.Message == "" {
+			rv.Status.Message = fmt.Sprint(e.attributes[errorMessage])
+		}
+	}
+	if isError, ok := s.tags[errorTag].(bool); ok && isError {
+		rv.Status.Code = statusCodeError
+	}
+	return rv
+}
+
+func newKeyValues(attributes map[string]interface{}) []keyValue {
+	rv := make([]keyValue, 0, len(attributes))
+	for k, v := range attributes {
+		rv = append(rv, newKeyValue(k, v))
+	}
+	sort.Slice(rv, func(i, j int) bool { return rv[i].Key < rv[j].Key })
+	return rv
+}
+
+func newKeyValue(key string, value interface{}) keyValue {
+	rv := keyValue{Key: key}
+	switch v := reflect.ValueOf(value); v.Kind() {
+	case reflect.Bool:
+		b := v.Bool()
+		rv.Value.BoolValue = &b
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i := strconv.FormatInt(v.Int(), 10)
+		rv.Value.IntValue = &i
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		i := strconv.FormatUint(v.Uint(), 10)
+		rv.Value.IntValue = &i
+	case reflect.Float32, reflect.Float64:
+		f := v.Float()
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			// JSON has no NaN and Inf numbers
+			s := fmt.Sprint(f)
+			rv.Value.StringValue = &s
+			break
+		}
+		rv.Value.DoubleValue = &f
+	default:
+		s := fmt.Sprint(value)
+		rv.Value.StringValue = &s
+	}
+	return rv
+}
@@ -0,0 +1,68 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlp
+
+import (
+	"net/http"
+	"time"
+)
+
+const (
+	defaultEndpoint    = "http://localhost:4318"
+	defaultBatchPeriod = 5 * time.Second
+	defaultBatchSize   = 512
+	tracesPath         = "/v1/traces"
+)
+
+type tracerOptions struct {
+	endpoint    string
+	client      *http.Client
+	batchPeriod time.Duration
+	batchSize   int
+}
+
+// Option is an option pattern for NewTracer
+type Option func(o *tracerOptions)
+
+// WithEndpoint sets base URL of the OTLP/HTTP collector, spans are sent to endpoint + "/v1/traces",
+// "http://localhost:4318" is used by default
+func WithEndpoint(endpoint string) Option {
+	return func(o *tracerOptions) {
+		o.endpoint = endpoint
+	}
+}
+
+// WithHTTPClient sets HTTP client used to send spans to the collector
+func WithHTTPClient(client *http.Client) Option {
+	return func(o *tracerOptions) {
+		o.client = client
+	}
+}
+
+// WithBatchPeriod sets how often the finished spans are sent to the collector
+func WithBatchPeriod(batchPeriod time.Duration) Option {
+	return func(o *tracerOptions) {
+		o.batchPeriod = batchPeriod
+	}
+}
+
+// WithBatchSize sets number of the finished spans causing immediate send to the collector
+func WithBatchSize(batchSize int) Option {
+	return func(o *tracerOptions) {
+		o.batchSize = batchSize
+	}
+}
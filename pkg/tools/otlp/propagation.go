@@ -0,0 +1,194 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlp
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/opentracing/opentracing-go"
+)
+
+const (
+	traceparentHeader = "traceparent"
+	tracestateHeader  = "tracestate"
+	baggageHeader     = "baggage"
+
+	traceparentVersion = "00"
+	sampledFlags       = "01"
+)
+
+type traceID [16]byte
+
+type spanID [8]byte
+
+func (id traceID) String() string {
+	return hex.EncodeToString(id[:])
+}
+
+func (id spanID) String() string {
+	return hex.EncodeToString(id[:])
+}
+
+func (id traceID) isValid() bool {
+	return id != traceID{}
+}
+
+func (id spanID) isValid() bool {
+	return id != spanID{}
+}
+
+func newTraceID() (id traceID) {
+	for !id.isValid() {
+		_, _ = rand.Read(id[:])
+	}
+	return id
+}
+
+func newSpanID() (id spanID) {
+	for !id.isValid() {
+		_, _ = rand.Read(id[:])
+	}
+	return id
+}
+
+// spanContext is W3C trace context of the span
+type spanContext struct {
+	traceID    traceID
+	spanID     spanID
+	traceState string
+	baggage    map[string]string
+}
+
+var _ opentracing.SpanContext = spanContext{}
+
+func (c spanContext) ForeachBaggageItem(handler func(k, v string) bool) {
+	for k, v := range c.baggage {
+		if !handler(k, v) {
+			return
+		}
+	}
+}
+
+func (c spanContext) withBaggageItem(key, value string) spanContext {
+	baggage := make(map[string]string, len(c.baggage)+1)
+	for k, v := range c.baggage {
+		baggage[k] = v
+	}
+	baggage[key] = value
+	c.baggage = baggage
+	return c
+}
+
+func (c spanContext) traceparent() string {
+	return strings.Join([]string{traceparentVersion, c.traceID.String(), c.spanID.String(), sampledFlags}, "-")
+}
+
+// inject writes c to carrier as W3C traceparent, tracestate and baggage headers
+func inject(c spanContext, carrier opentracing.TextMapWriter) {
+	carrier.Set(traceparentHeader, c.traceparent())
+	if c.traceState != "" {
+		carrier.Set(tracestateHeader, c.traceState)
+	}
+	if len(c.baggage) > 0 {
+		var members []string
+		for k, v := range c.baggage {
+			members = append(members, url.QueryEscape(k)+"="+url.QueryEscape(v))
+		}
+		carrier.Set(baggageHeader, strings.Join(members, ","))
+	}
+}
+
+// extract reads W3C traceparent, tracestate and baggage headers from carrier
+func extract(carrier opentracing.TextMapReader) (spanContext, error) {
+	var traceparent, traceState, baggage string
+	err := carrier.ForeachKey(func(key, val string) error {
+		switch strings.ToLower(key) {
+		case traceparentHeader:
+			traceparent = val
+		case tracestateHeader:
+			traceState = val
+		case baggageHeader:
+			baggage = val
+		}
+		return nil
+	})
+	if err != nil {
+		return spanContext{}, err
+	}
+	if traceparent == "" {
+		return spanContext{}, opentracing.ErrSpanContextNotFound
+	}
+
+	c, err := parseTraceparent(traceparent)
+	if err != nil {
+		return spanContext{}, opentracing.ErrSpanContextCorrupted
+	}
+	c.traceState = traceState
+	c.baggage = parseBaggage(baggage)
+	return c, nil
+}
+
+func parseTraceparent(traceparent string) (c spanContext, err error) {
+	parts := strings.Split(strings.TrimSpace(traceparent), "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || parts[0] == "ff" || (parts[0] == traceparentVersion && len(parts) != 4) {
+		return c, fmt.Errorf("invalid traceparent: %s", traceparent)
+	}
+	if err = decodeHex(c.traceID[:], parts[1]); err != nil {
+		return c, err
+	}
+	if err = decodeHex(c.spanID[:], parts[2]); err != nil {
+		return c, err
+	}
+	if !c.traceID.isValid() || !c.spanID.isValid() {
+		return c, fmt.Errorf("invalid traceparent: %s", traceparent)
+	}
+	return c, nil
+}
+
+func decodeHex(dst []byte, s string) error {
+	if len(s) != hex.EncodedLen(len(dst)) || strings.ToLower(s) != s {
+		return fmt.Errorf("invalid id: %s", s)
+	}
+	_, err := hex.Decode(dst, []byte(s))
+	return err
+}
+
+func parseBaggage(baggage string) map[string]string {
+	if baggage == "" {
+		return nil
+	}
+	rv := make(map[string]string)
+	for _, member := range strings.Split(baggage, ",") {
+		// Properties of the baggage members are not supported
+		member = strings.SplitN(member, ";", 2)[0]
+		kv := strings.SplitN(member, "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		k, kErr := url.QueryUnescape(strings.TrimSpace(kv[0]))
+		v, vErr := url.QueryUnescape(strings.TrimSpace(kv[1]))
+		if kErr != nil || vErr != nil || k == "" {
+			continue
+		}
+		rv[k] = v
+	}
+	return rv
+}
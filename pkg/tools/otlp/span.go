@@ -0,0 +1,181 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlp
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/log"
+)
+
+const defaultEventName = "log"
+
+type event struct {
+	time       time.Time
+	name       string
+	attributes map[string]interface{}
+}
+
+type span struct {
+	tracer   *Tracer
+	parentID spanID
+	start    time.Time
+
+	mutex    sync.Mutex
+	context  spanContext
+	name     string
+	end      time.Time
+	tags     map[string]interface{}
+	events   []event
+	finished bool
+}
+
+var _ opentracing.Span = &span{}
+
+func (s *span) Finish() {
+	s.FinishWithOptions(opentracing.FinishOptions{})
+}
+
+func (s *span) FinishWithOptions(opts opentracing.FinishOptions) {
+	s.mutex.Lock()
+	if s.finished {
+		s.mutex.Unlock()
+		return
+	}
+	s.finished = true
+	s.end = opts.FinishTime
+	if s.end.IsZero() {
+		s.end = time.Now()
+	}
+	for _, record := range opts.LogRecords {
+		s.logFields(record.Timestamp, record.Fields)
+	}
+	for _, data := range opts.BulkLogData {
+		record := data.ToLogRecord()
+		s.logFields(record.Timestamp, record.Fields)
+	}
+	s.mutex.Unlock()
+
+	s.tracer.finished(s)
+}
+
+func (s *span) Context() opentracing.SpanContext {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.context
+}
+
+func (s *span) SetOperationName(operationName string) opentracing.Span {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.name = operationName
+	return s
+}
+
+func (s *span) SetTag(key string, value interface{}) opentracing.Span {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.tags[key] = value
+	return s
+}
+
+func (s *span) LogFields(fields ...log.Field) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.logFields(time.Now(), fields)
+}
+
+func (s *span) LogKV(alternatingKeyValues ...interface{}) {
+	fields, err := log.InterleavedKVToFields(alternatingKeyValues...)
+	if err != nil {
+		fields = []log.Field{log.Error(err), log.String("function", "LogKV")}
+	}
+	s.LogFields(fields...)
+}
+
+// logFields adds an event named by "event" field, s.mutex should be locked
+func (s *span) logFields(timestamp time.Time, fields []log.Field) {
+	if s.finished && timestamp.IsZero() {
+		return
+	}
+	if timestamp.IsZero() {
+		timestamp = time.Now()
+	}
+	e := event{
+		time:       timestamp,
+		name:       defaultEventName,
+		attributes: make(map[string]interface{}, len(fields)),
+	}
+	for _, field := range fields {
+		if field.Key() == "event" {
+			e.name = fmt.Sprint(field.Value())
+			continue
+		}
+		e.attributes[field.Key()] = field.Value()
+	}
+	s.events = append(s.events, e)
+}
+
+func (s *span) SetBaggageItem(restrictedKey, value string) opentracing.Span {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.context = s.context.withBaggageItem(restrictedKey, value)
+	return s
+}
+
+func (s *span) BaggageItem(restrictedKey string) string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.context.baggage[restrictedKey]
+}
+
+func (s *span) Tracer() opentracing.Tracer {
+	return s.tracer
+}
+
+func (s *span) LogEvent(event string) {
+	s.LogFields(log.String("event", event))
+}
+
+func (s *span) LogEventWithPayload(event string, payload interface{}) {
+	s.LogFields(log.String("event", event), log.Object("payload", payload))
+}
+
+func (s *span) Log(data opentracing.LogData) {
+	record := data.ToLogRecord()
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.logFields(record.Timestamp, record.Fields)
+}
+
+func (s *span) String() string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.context.traceparent()
+}
@@ -0,0 +1,187 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package otlp provides opentracing.Tracer with OpenTelemetry semantics: spans are exported to OpenTelemetry collector
+// with OTLP/HTTP JSON protocol and trace context is propagated with W3C Trace Context traceparent and tracestate headers
+//
+// TODO - replace the package with go.opentelemetry.io/otel SDK, its OTLP exporter and the OpenTracing bridge. They
+//        need google.golang.org/grpc v1.46+ while the module is pinned to v1.29, so the replacement should land
+//        together with the grpc upgrade.
+package otlp
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/sirupsen/logrus"
+)
+
+// Tracer is opentracing.Tracer exporting spans to OpenTelemetry collector
+type Tracer struct {
+	service  string
+	endpoint string
+	client   *http.Client
+	period   time.Duration
+	size     int
+
+	mutex   sync.Mutex
+	batch   []*span
+	flushCh chan struct{}
+	cancel  context.CancelFunc
+	done    chan struct{}
+}
+
+var _ opentracing.Tracer = &Tracer{}
+
+// NewTracer creates Tracer for the service and starts exporting the finished spans, Close should be called to flush
+// the remaining spans and stop exporting
+func NewTracer(service string, options ...Option) *Tracer {
+	o := &tracerOptions{
+		endpoint:    defaultEndpoint,
+		client:      http.DefaultClient,
+		batchPeriod: defaultBatchPeriod,
+		batchSize:   defaultBatchSize,
+	}
+	for _, opt := range options {
+		opt(o)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t := &Tracer{
+		service:  service,
+		endpoint: strings.TrimSuffix(o.endpoint, "/") + tracesPath,
+		client:   o.client,
+		period:   o.batchPeriod,
+		size:     o.batchSize,
+		flushCh:  make(chan struct{}, 1),
+		cancel:   cancel,
+		done:     make(chan struct{}),
+	}
+	go t.run(ctx)
+	return t
+}
+
+// StartSpan starts a new span, it is a child of the first ChildOf or FollowsFrom reference created by this tracer
+func (t *Tracer) StartSpan(operationName string, opts ...opentracing.StartSpanOption) opentracing.Span {
+	options := new(opentracing.StartSpanOptions)
+	for _, opt := range opts {
+		opt.Apply(options)
+	}
+
+	s := &span{
+		tracer: t,
+		name:   operationName,
+		start:  options.StartTime,
+		tags:   make(map[string]interface{}, len(options.Tags)),
+	}
+	if s.start.IsZero() {
+		s.start = time.Now()
+	}
+	for k, v := range options.Tags {
+		s.tags[k] = v
+	}
+
+	for _, ref := range options.References {
+		if parent, ok := ref.ReferencedContext.(spanContext); ok && parent.traceID.isValid() {
+			s.context = parent
+			s.parentID = parent.spanID
+			break
+		}
+	}
+	if !s.context.traceID.isValid() {
+		s.context.traceID = newTraceID()
+	}
+	s.context.spanID = newSpanID()
+
+	return s
+}
+
+// Inject writes W3C trace context of sm to carrier, opentracing.HTTPHeaders and opentracing.TextMap formats are
+// supported
+func (t *Tracer) Inject(sm opentracing.SpanContext, format, carrier interface{}) error {
+	c, ok := sm.(spanContext)
+	if !ok {
+		return opentracing.ErrInvalidSpanContext
+	}
+	if format != opentracing.HTTPHeaders && format != opentracing.TextMap {
+		return opentracing.ErrUnsupportedFormat
+	}
+	writer, ok := carrier.(opentracing.TextMapWriter)
+	if !ok {
+		return opentracing.ErrInvalidCarrier
+	}
+	inject(c, writer)
+	return nil
+}
+
+// Extract reads W3C trace context from carrier, opentracing.HTTPHeaders and opentracing.TextMap formats are
+// supported
+func (t *Tracer) Extract(format, carrier interface{}) (opentracing.SpanContext, error) {
+	if format != opentracing.HTTPHeaders && format != opentracing.TextMap {
+		return nil, opentracing.ErrUnsupportedFormat
+	}
+	reader, ok := carrier.(opentracing.TextMapReader)
+	if !ok {
+		return nil, opentracing.ErrInvalidCarrier
+	}
+	c, err := extract(reader)
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Close sends the remaining finished spans and stops exporting
+func (t *Tracer) Close() error {
+	t.cancel()
+	<-t.done
+	return t.export()
+}
+
+func (t *Tracer) finished(s *span) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.batch = append(t.batch, s)
+	if len(t.batch) >= t.size {
+		select {
+		case t.flushCh <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (t *Tracer) run(ctx context.Context) {
+	defer close(t.done)
+
+	ticker := time.NewTicker(t.period)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-t.flushCh:
+		}
+		if err := t.export(); err != nil {
+			logrus.Errorf("failed to export spans to %s: %v", t.endpoint, err)
+		}
+	}
+}
@@ -0,0 +1,255 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlp_test
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-opentracing/go/otgrpc"
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+	"github.com/opentracing/opentracing-go/log"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/otlp"
+)
+
+var traceparentRegexp = regexp.MustCompile(`^00-([0-9a-f]{32})-([0-9a-f]{16})-01$`)
+
+func startCollector(t *testing.T) (*httptest.Server, <-chan *exportRequest) {
+	requestCh := make(chan *exportRequest, 10)
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/v1/traces", r.URL.Path)
+		require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		request := new(exportRequest)
+		require.NoError(t, json.Unmarshal(body, request))
+		requestCh <- request
+	}))
+	return collector, requestCh
+}
+
+func TestTracer_Propagation(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	collector, _ := startCollector(t)
+	defer collector.Close()
+
+	tracer := otlp.NewTracer("test", otlp.WithEndpoint(collector.URL))
+	defer func() { require.NoError(t, tracer.Close()) }()
+
+	parent := tracer.StartSpan("parent")
+	parent.SetBaggageItem("key", "value with spaces")
+
+	carrier := opentracing.TextMapCarrier{}
+	require.NoError(t, tracer.Inject(parent.Context(), opentracing.TextMap, carrier))
+	require.Regexp(t, traceparentRegexp, carrier["traceparent"])
+	require.Equal(t, "key=value+with+spaces", carrier["baggage"])
+
+	spanContext, err := tracer.Extract(opentracing.HTTPHeaders, opentracing.HTTPHeadersCarrier(http.Header{
+		"Traceparent": []string{carrier["traceparent"]},
+		"Tracestate":  []string{"vendor=value"},
+		"Baggage":     []string{carrier["baggage"]},
+	}))
+	require.NoError(t, err)
+
+	child := tracer.StartSpan("child", opentracing.ChildOf(spanContext))
+	require.Equal(t, "value with spaces", child.BaggageItem("key"))
+
+	childCarrier := opentracing.TextMapCarrier{}
+	require.NoError(t, tracer.Inject(child.Context(), opentracing.TextMap, childCarrier))
+	require.Equal(t, "vendor=value", childCarrier["tracestate"])
+
+	parentIDs := traceparentRegexp.FindStringSubmatch(carrier["traceparent"])
+	childIDs := traceparentRegexp.FindStringSubmatch(childCarrier["traceparent"])
+	require.Equal(t, parentIDs[1], childIDs[1])
+	require.NotEqual(t, parentIDs[2], childIDs[2])
+
+	_, err = tracer.Extract(opentracing.TextMap, opentracing.TextMapCarrier{})
+	require.Equal(t, opentracing.ErrSpanContextNotFound, err)
+
+	_, err = tracer.Extract(opentracing.TextMap, opentracing.TextMapCarrier{
+		"traceparent": "00-00000000000000000000000000000000-0000000000000000-01",
+	})
+	require.Equal(t, opentracing.ErrSpanContextCorrupted, err)
+
+	child.Finish()
+	parent.Finish()
+}
+
+type exportRequest struct {
+	ResourceSpans []struct {
+		Resource struct {
+			Attributes []keyValue `json:"attributes"`
+		} `json:"resource"`
+		ScopeSpans []struct {
+			Spans []struct {
+				TraceID      string     `json:"traceId"`
+				SpanID       string     `json:"spanId"`
+				ParentSpanID string     `json:"parentSpanId"`
+				Name         string     `json:"name"`
+				Kind         int        `json:"kind"`
+				Attributes   []keyValue `json:"attributes"`
+				Events       []struct {
+					Name       string     `json:"name"`
+					Attributes []keyValue `json:"attributes"`
+				} `json:"events"`
+				Status struct {
+					Code    int    `json:"code"`
+					Message string `json:"message"`
+				} `json:"status"`
+			} `json:"spans"`
+		} `json:"scopeSpans"`
+	} `json:"resourceSpans"`
+}
+
+type keyValue struct {
+	Key   string `json:"key"`
+	Value struct {
+		StringValue string `json:"stringValue"`
+		IntValue    string `json:"intValue"`
+		BoolValue   bool   `json:"boolValue"`
+	} `json:"value"`
+}
+
+func TestTracer_Export(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	collector, requestCh := startCollector(t)
+	defer collector.Close()
+
+	tracer := otlp.NewTracer("test-service", otlp.WithEndpoint(collector.URL), otlp.WithBatchSize(2), otlp.WithBatchPeriod(time.Hour))
+	defer func() { require.NoError(t, tracer.Close()) }()
+
+	parent := tracer.StartSpan("parent")
+	ext.SpanKindRPCServer.Set(parent)
+	ext.Error.Set(parent, true)
+	parent.SetTag("count", 42)
+	parent.LogFields(log.String("event", "error"), log.String("message", "failed"))
+
+	child := tracer.StartSpan("child", opentracing.ChildOf(parent.Context()))
+	child.Finish()
+	parent.Finish()
+
+	var request *exportRequest
+	select {
+	case request = <-requestCh:
+	case <-time.After(time.Second):
+		require.FailNow(t, "spans are not exported")
+	}
+
+	require.Len(t, request.ResourceSpans, 1)
+	require.Equal(t, "service.name", request.ResourceSpans[0].Resource.Attributes[0].Key)
+	require.Equal(t, "test-service", request.ResourceSpans[0].Resource.Attributes[0].Value.StringValue)
+
+	spans := request.ResourceSpans[0].ScopeSpans[0].Spans
+	require.Len(t, spans, 2)
+	childSpan, parentSpan := spans[0], spans[1]
+
+	require.Equal(t, "child", childSpan.Name)
+	require.Equal(t, 1, childSpan.Kind)
+	require.Equal(t, parentSpan.TraceID, childSpan.TraceID)
+	require.Equal(t, parentSpan.SpanID, childSpan.ParentSpanID)
+
+	require.Equal(t, "parent", parentSpan.Name)
+	require.Equal(t, 2, parentSpan.Kind)
+	require.Empty(t, parentSpan.ParentSpanID)
+	require.Equal(t, 2, parentSpan.Status.Code)
+	require.Equal(t, "failed", parentSpan.Status.Message)
+	require.Len(t, parentSpan.Events, 1)
+	require.Equal(t, "error", parentSpan.Events[0].Name)
+
+	var count string
+	for _, attribute := range parentSpan.Attributes {
+		if attribute.Key == "count" {
+			count = attribute.Value.IntValue
+		}
+	}
+	require.Equal(t, "42", count)
+}
+
+func TestTracer_GRPC(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	collector, _ := startCollector(t)
+	defer collector.Close()
+
+	tracer := otlp.NewTracer("test", otlp.WithEndpoint(collector.URL))
+	defer func() { require.NoError(t, tracer.Close()) }()
+
+	var traceparent string
+	var serverSpanContext opentracing.SpanContext
+	server := grpc.NewServer(grpc.UnaryInterceptor(func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		if md, ok := metadata.FromIncomingContext(ctx); ok && len(md.Get("traceparent")) > 0 {
+			traceparent = md.Get("traceparent")[0]
+		}
+		return otgrpc.OpenTracingServerInterceptor(tracer)(ctx, req, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			serverSpanContext = opentracing.SpanFromContext(ctx).Context()
+			return handler(ctx, req)
+		})
+	}))
+	grpc_health_v1.RegisterHealthServer(server, health.NewServer())
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() { _ = server.Serve(listener) }()
+	defer server.Stop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	cc, err := grpc.DialContext(ctx, listener.Addr().String(), grpc.WithInsecure(), grpc.WithBlock(),
+		grpc.WithUnaryInterceptor(otgrpc.OpenTracingClientInterceptor(tracer)))
+	require.NoError(t, err)
+	defer func() { _ = cc.Close() }()
+
+	span := tracer.StartSpan("client")
+	defer span.Finish()
+
+	_, err = grpc_health_v1.NewHealthClient(cc).Check(opentracing.ContextWithSpan(ctx, span), &grpc_health_v1.HealthCheckRequest{})
+	require.NoError(t, err)
+
+	require.Regexp(t, traceparentRegexp, traceparent)
+
+	carrier := opentracing.TextMapCarrier{}
+	require.NoError(t, tracer.Inject(span.Context(), opentracing.TextMap, carrier))
+	serverCarrier := opentracing.TextMapCarrier{}
+	require.NoError(t, tracer.Inject(serverSpanContext, opentracing.TextMap, serverCarrier))
+	require.Equal(t,
+		traceparentRegexp.FindStringSubmatch(carrier["traceparent"])[1],
+		traceparentRegexp.FindStringSubmatch(serverCarrier["traceparent"])[1])
+}
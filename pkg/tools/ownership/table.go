@@ -0,0 +1,104 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ownership keeps owners of the registered names, so the registry elements can tell the identity that has
+// registered a name from the others
+package ownership
+
+import (
+	"time"
+
+	"github.com/golang/protobuf/ptypes/timestamp"
+)
+
+// pruneInterval is how often Set removes all the expired owners
+const pruneInterval = time.Minute
+
+// Owner is the identity owning a name until ExpirationTime, zero ExpirationTime means the name never expires
+type Owner struct {
+	Identity       string
+	ExpirationTime time.Time
+}
+
+// NewOwner creates an Owner of a registration expiring at expirationTime, nil expirationTime means it never expires
+func NewOwner(identity string, expirationTime *timestamp.Timestamp) *Owner {
+	o := &Owner{Identity: identity}
+	if expirationTime != nil {
+		o.ExpirationTime = time.Unix(expirationTime.Seconds, int64(expirationTime.Nanos))
+	}
+	return o
+}
+
+// Expired returns true if the ownership has expired
+func (o *Owner) Expired() bool {
+	return !o.ExpirationTime.IsZero() && time.Now().After(o.ExpirationTime)
+}
+
+// Table is a map of the names to their owners. Expired owners are not returned and are removed from the table
+// periodically, so names registered once and never unregistered don't pile up.
+// Table is not safe for concurrent use, its users check and claim the names under their own locks.
+type Table struct {
+	owners    map[string]*Owner
+	lastPrune time.Time
+}
+
+// NewTable creates an empty Table
+func NewTable() *Table {
+	return &Table{
+		owners:    map[string]*Owner{},
+		lastPrune: time.Now(),
+	}
+}
+
+// Get returns the not expired owner of name, nil if there is no one
+func (t *Table) Get(name string) *Owner {
+	o, ok := t.owners[name]
+	if !ok {
+		return nil
+	}
+	if o.Expired() {
+		delete(t.owners, name)
+		return nil
+	}
+	return o
+}
+
+// Set makes o the owner of name, nil o removes the owner
+func (t *Table) Set(name string, o *Owner) {
+	if time.Since(t.lastPrune) > pruneInterval {
+		t.Prune()
+	}
+	if o == nil {
+		delete(t.owners, name)
+		return
+	}
+	t.owners[name] = o
+}
+
+// Prune removes all the expired owners
+func (t *Table) Prune() {
+	t.lastPrune = time.Now()
+	for name, o := range t.owners {
+		if o.Expired() {
+			delete(t.owners, name)
+		}
+	}
+}
+
+// Len returns the number of the owners in the table, including the expired but not yet removed ones
+func (t *Table) Len() int {
+	return len(t.owners)
+}
@@ -0,0 +1,61 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ownership_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/ownership"
+)
+
+func TestTable(t *testing.T) {
+	table := ownership.NewTable()
+	expirationTime := time.Now().Add(time.Millisecond * 50)
+	table.Set("nse-1", ownership.NewOwner("a", &timestamp.Timestamp{
+		Seconds: expirationTime.Unix(),
+		Nanos:   int32(expirationTime.Nanosecond()),
+	}))
+	table.Set("nse-2", ownership.NewOwner("b", nil))
+	require.Equal(t, "a", table.Get("nse-1").Identity)
+	require.Equal(t, "b", table.Get("nse-2").Identity)
+
+	<-time.After(time.Millisecond * 100)
+	require.Nil(t, table.Get("nse-1"))
+	require.Equal(t, "b", table.Get("nse-2").Identity)
+
+	table.Set("nse-2", nil)
+	require.Nil(t, table.Get("nse-2"))
+}
+
+func TestTable_Prune(t *testing.T) {
+	table := ownership.NewTable()
+	expirationTime := time.Now().Add(-time.Second)
+	for _, name := range []string{"nse-1", "nse-2", "nse-3"} {
+		table.Set(name, ownership.NewOwner("a", &timestamp.Timestamp{
+			Seconds: expirationTime.Unix(),
+			Nanos:   int32(expirationTime.Nanosecond()),
+		}))
+	}
+	table.Set("nse-4", ownership.NewOwner("a", nil))
+	require.Equal(t, 4, table.Len())
+	table.Prune()
+	require.Equal(t, 1, table.Len())
+}
@@ -0,0 +1,71 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package peerid provides utilities for getting an identity of the gRPC peer
+package peerid
+
+import (
+	"context"
+	"crypto/x509"
+
+	"github.com/pkg/errors"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+// Certificate returns the leaf certificate of the peer from authInfo, nil if there is no one
+func Certificate(authInfo credentials.AuthInfo) *x509.Certificate {
+	switch v := authInfo.(type) {
+	case *credentials.TLSInfo:
+		if len(v.State.PeerCertificates) > 0 {
+			return v.State.PeerCertificates[0]
+		}
+	case credentials.TLSInfo:
+		if len(v.State.PeerCertificates) > 0 {
+			return v.State.PeerCertificates[0]
+		}
+	}
+	return nil
+}
+
+// FromAuthInfo returns SPIFFE ID of the peer from its certificate in authInfo
+func FromAuthInfo(authInfo credentials.AuthInfo) (spiffeid.ID, error) {
+	cert := Certificate(authInfo)
+	if cert == nil {
+		return spiffeid.ID{}, errors.New("no peer certificate")
+	}
+	return x509svid.IDFromCert(cert)
+}
+
+// FromContext returns SPIFFE ID of the gRPC peer stored in ctx
+func FromContext(ctx context.Context) (spiffeid.ID, error) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return spiffeid.ID{}, errors.New("no peer in context")
+	}
+	return FromAuthInfo(p.AuthInfo)
+}
+
+// Identity returns SPIFFE ID of the gRPC peer stored in ctx as a string, empty if the peer has no SVID
+func Identity(ctx context.Context) string {
+	id, err := FromContext(ctx)
+	if err != nil {
+		return ""
+	}
+	return id.String()
+}
@@ -0,0 +1,174 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redact
+
+import (
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// redactMessage redacts the fields of m matching the registered rules, pending are the remaining parts of the rule
+// paths of the parent messages, r.mutex should be locked
+func (r *Registry) redactMessage(path string, m protoreflect.Message, pending [][]string) {
+	rules := append(append([][]string(nil), pending...), r.fields[m.Descriptor().FullName()]...)
+
+	// m should not be modified during Range
+	var fields []protoreflect.FieldDescriptor
+	m.Range(func(fd protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+		fields = append(fields, fd)
+		return true
+	})
+
+	for _, fd := range fields {
+		name := string(fd.Name())
+		fieldPath := joinPath(path, name)
+
+		var whole bool
+		var next [][]string
+		for _, rule := range rules {
+			if rule[0] != name {
+				continue
+			}
+			if len(rule) == 1 {
+				whole = true
+				break
+			}
+			next = append(next, rule[1:])
+		}
+
+		switch {
+		case whole:
+			redactField(m, fd)
+		case fd.IsMap():
+			r.redactMap(fieldPath, m.Mutable(fd).Map(), fd.MapValue(), next)
+		case fd.IsList():
+			r.redactList(fieldPath, m.Mutable(fd).List(), fd, next)
+		case fd.Message() != nil:
+			r.redactMessage(fieldPath, m.Mutable(fd).Message(), next)
+		case fd.Kind() == protoreflect.StringKind:
+			if r.matches(fieldPath, m.Get(fd).String()) {
+				m.Set(fd, protoreflect.ValueOfString(Redacted))
+			}
+		}
+	}
+}
+
+func (r *Registry) redactMap(path string, m protoreflect.Map, valueFd protoreflect.FieldDescriptor, pending [][]string) {
+	var keys []protoreflect.MapKey
+	m.Range(func(key protoreflect.MapKey, _ protoreflect.Value) bool {
+		keys = append(keys, key)
+		return true
+	})
+
+	for _, key := range keys {
+		keyPath := joinPath(path, key.String())
+
+		var whole bool
+		var next [][]string
+		for _, rule := range pending {
+			if rule[0] != key.String() {
+				continue
+			}
+			if len(rule) == 1 {
+				whole = true
+				break
+			}
+			next = append(next, rule[1:])
+		}
+
+		switch {
+		case whole:
+			m.Set(key, redactedValue(valueFd, m.Get(key)))
+		case valueFd.Message() != nil:
+			r.redactMessage(keyPath, m.Mutable(key).Message(), next)
+		case valueFd.Kind() == protoreflect.StringKind:
+			if r.matches(keyPath, m.Get(key).String()) {
+				m.Set(key, protoreflect.ValueOfString(Redacted))
+			}
+		}
+	}
+}
+
+func (r *Registry) redactList(path string, l protoreflect.List, fd protoreflect.FieldDescriptor, pending [][]string) {
+	for i := 0; i < l.Len(); i++ {
+		switch {
+		case fd.Message() != nil:
+			r.redactMessage(path, l.Get(i).Message(), pending)
+		case fd.Kind() == protoreflect.StringKind:
+			if r.matches(path, l.Get(i).String()) {
+				l.Set(i, protoreflect.ValueOfString(Redacted))
+			}
+		}
+	}
+}
+
+func (r *Registry) matches(path, value string) bool {
+	for _, predicate := range r.predicates {
+		if predicate(path, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactField redacts the whole field: strings and bytes are replaced with Redacted, other fields are cleared
+func redactField(m protoreflect.Message, fd protoreflect.FieldDescriptor) {
+	switch {
+	case fd.IsMap():
+		mp := m.Mutable(fd).Map()
+		var keys []protoreflect.MapKey
+		mp.Range(func(key protoreflect.MapKey, _ protoreflect.Value) bool {
+			keys = append(keys, key)
+			return true
+		})
+		for _, key := range keys {
+			mp.Set(key, redactedValue(fd.MapValue(), mp.Get(key)))
+		}
+	case fd.IsList() && fd.Message() != nil:
+		m.Clear(fd)
+	case fd.IsList():
+		l := m.Mutable(fd).List()
+		for i := 0; i < l.Len(); i++ {
+			l.Set(i, redactedValue(fd, l.Get(i)))
+		}
+	case fd.Kind() == protoreflect.StringKind || fd.Kind() == protoreflect.BytesKind:
+		m.Set(fd, redactedValue(fd, m.Get(fd)))
+	default:
+		m.Clear(fd)
+	}
+}
+
+// redactedValue returns Redacted value of fd kind, values of other kinds are replaced with the default value
+func redactedValue(fd protoreflect.FieldDescriptor, value protoreflect.Value) protoreflect.Value {
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString(Redacted)
+	case protoreflect.BytesKind:
+		return protoreflect.ValueOfBytes([]byte(Redacted))
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		msg := value.Message()
+		return protoreflect.ValueOfMessage(msg.Type().New())
+	default:
+		return fd.Default()
+	}
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + pathSeparator + name
+}
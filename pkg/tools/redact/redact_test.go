@@ -0,0 +1,109 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redact_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/common"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/redact"
+)
+
+func newRequest() *networkservice.NetworkServiceRequest {
+	return &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id:     "id",
+			Labels: map[string]string{"app": "nsc", "password": "secret"},
+			Mechanism: &networkservice.Mechanism{
+				Type: kernel.MECHANISM,
+				Parameters: map[string]string{
+					common.NetNSInodeKey:    "12345",
+					kernel.SocketFilename:   "/var/lib/networkservicemesh/nsm.io.sock",
+					common.InterfaceNameKey: "nsm-1",
+				},
+			},
+			Path: &networkservice.Path{
+				PathSegments: []*networkservice.PathSegment{
+					{Name: "nsc", Token: "token-1"},
+					{Name: "nsmgr", Token: "token-2"},
+				},
+			},
+		},
+	}
+}
+
+func TestMessage_Default(t *testing.T) {
+	request := newRequest()
+	redacted := redact.Message(request).(*networkservice.NetworkServiceRequest)
+
+	require.True(t, proto.Equal(newRequest(), request), "original message should not be modified")
+
+	for _, segment := range redacted.GetConnection().GetPath().GetPathSegments() {
+		require.Equal(t, redact.Redacted, segment.GetToken())
+	}
+	parameters := redacted.GetConnection().GetMechanism().GetParameters()
+	require.Equal(t, redact.Redacted, parameters[common.NetNSInodeKey])
+	require.Equal(t, redact.Redacted, parameters[kernel.SocketFilename])
+	require.Equal(t, "nsm-1", parameters[common.InterfaceNameKey])
+	require.Equal(t, "secret", redacted.GetConnection().GetLabels()["password"])
+
+	require.Nil(t, redact.Message((*networkservice.Connection)(nil)))
+	require.Equal(t, "value", redact.Value("value"))
+}
+
+func TestRegistry_Field(t *testing.T) {
+	r := redact.NewRegistry()
+	r.RegisterField(new(networkservice.NetworkServiceRequest), "connection.labels.password")
+	r.RegisterField(new(networkservice.Connection), "path")
+
+	redacted := r.Message(newRequest()).(*networkservice.NetworkServiceRequest)
+
+	require.Equal(t, redact.Redacted, redacted.GetConnection().GetLabels()["password"])
+	require.Equal(t, "nsc", redacted.GetConnection().GetLabels()["app"])
+	require.Nil(t, redacted.GetConnection().GetPath())
+	require.Equal(t, "12345", redacted.GetConnection().GetMechanism().GetParameters()[common.NetNSInodeKey])
+}
+
+func TestRegistry_Predicate(t *testing.T) {
+	r := redact.NewRegistry()
+	r.RegisterPredicate(func(path, value string) bool {
+		return strings.HasPrefix(path, "network_service_labels.") && strings.HasPrefix(value, "secret")
+	})
+	r.RegisterPredicate(func(path, _ string) bool {
+		return path == "network_service_names"
+	})
+
+	nse := &registry.NetworkServiceEndpoint{
+		Name:                "nse",
+		NetworkServiceNames: []string{"ns-1", "ns-2"},
+		NetworkServiceLabels: map[string]*registry.NetworkServiceLabels{
+			"ns-1": {Labels: map[string]string{"token": "secret-1", "app": "nse"}},
+		},
+	}
+	redacted := r.Message(nse).(*registry.NetworkServiceEndpoint)
+
+	require.Equal(t, "nse", redacted.GetName())
+	require.Equal(t, []string{redact.Redacted, redact.Redacted}, redacted.GetNetworkServiceNames())
+	require.Equal(t, map[string]string{"token": redact.Redacted, "app": "nse"}, redacted.GetNetworkServiceLabels()["ns-1"].GetLabels())
+}
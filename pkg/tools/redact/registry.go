@@ -0,0 +1,130 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package redact provides a central registry of the sensitive fields of the proto messages which should be redacted
+// before the messages are emitted to traces, logs or journal
+package redact
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/common"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
+)
+
+// Redacted is the value replacing the redacted string and bytes values
+const Redacted = "REDACTED"
+
+const pathSeparator = "."
+
+// Predicate returns true if the string value at the path should be redacted. Path consists of the proto field names
+// and the map keys from the root message joined with ".", e.g. "connection.mechanism.parameters.netnsInode"
+type Predicate func(path, value string) bool
+
+// Registry is a set of the redaction rules
+type Registry struct {
+	mutex      sync.RWMutex
+	fields     map[protoreflect.FullName][][]string
+	predicates []Predicate
+}
+
+// NewRegistry creates Registry without rules
+func NewRegistry() *Registry {
+	return &Registry{
+		fields: make(map[protoreflect.FullName][][]string),
+	}
+}
+
+// DefaultRegistry is the registry used by the package functions, it redacts PathSegment.Token and file socket and
+// netns inode parameters of the mechanisms
+var DefaultRegistry = newDefaultRegistry()
+
+func newDefaultRegistry() *Registry {
+	r := NewRegistry()
+	r.RegisterField(new(networkservice.PathSegment), "token")
+	r.RegisterField(new(networkservice.Mechanism), "parameters."+kernel.SocketFilename)
+	r.RegisterField(new(networkservice.Mechanism), "parameters."+common.NetNSInodeKey)
+	return r
+}
+
+// RegisterField registers the field at the path relative to the messages of msg type. Path consists of the proto field
+// names and optionally the map key joined with ".", e.g. "path.path_segments.token" for networkservice.Connection or
+// "parameters.netnsInode" for networkservice.Mechanism. Whole message, list and map fields may be registered.
+func (r *Registry) RegisterField(msg proto.Message, path string) {
+	fullName := proto.MessageReflect(msg).Descriptor().FullName()
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.fields[fullName] = append(r.fields[fullName], strings.Split(path, pathSeparator))
+}
+
+// RegisterPredicate registers predicate checking every string value of the messages
+func (r *Registry) RegisterPredicate(predicate Predicate) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.predicates = append(r.predicates, predicate)
+}
+
+// Message returns a copy of msg with the registered fields redacted, msg is not modified
+func (r *Registry) Message(msg proto.Message) proto.Message {
+	if msg == nil || reflect.ValueOf(msg).IsNil() {
+		return msg
+	}
+	msg = proto.Clone(msg)
+
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	r.redactMessage("", proto.MessageReflect(msg), nil)
+	return msg
+}
+
+// Value returns Message(value) if value is a proto message, otherwise value
+func (r *Registry) Value(value interface{}) interface{} {
+	if msg, ok := value.(proto.Message); ok {
+		return r.Message(msg)
+	}
+	return value
+}
+
+// RegisterField registers the field at the path relative to the messages of msg type in DefaultRegistry
+func RegisterField(msg proto.Message, path string) {
+	DefaultRegistry.RegisterField(msg, path)
+}
+
+// RegisterPredicate registers predicate in DefaultRegistry
+func RegisterPredicate(predicate Predicate) {
+	DefaultRegistry.RegisterPredicate(predicate)
+}
+
+// Message returns a copy of msg with the fields registered in DefaultRegistry redacted
+func Message(msg proto.Message) proto.Message {
+	return DefaultRegistry.Message(msg)
+}
+
+// Value returns a copy of value with the fields registered in DefaultRegistry redacted if value is a proto message,
+// otherwise value
+func Value(value interface{}) interface{} {
+	return DefaultRegistry.Value(value)
+}
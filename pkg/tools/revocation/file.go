@@ -0,0 +1,70 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package revocation
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"time"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// WatchFile loads JSON Document from the file at path to the list and then reloads it every period while ctx is not
+// done. If the file is failed to load later, the list keeps the last loaded content and the error is logged.
+func (l *List) WatchFile(ctx context.Context, path string, period time.Duration) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	doc, err := Parse(data)
+	if err != nil {
+		return err
+	}
+	l.Update(doc)
+
+	go func() {
+		logEntry := log.Entry(ctx).WithField("revocation", path)
+		ticker := time.NewTicker(period)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			newData, err := ioutil.ReadFile(path)
+			if err != nil {
+				logEntry.Errorf("failed to read revocation list: %v", err)
+				continue
+			}
+			if bytes.Equal(data, newData) {
+				continue
+			}
+			doc, err := Parse(newData)
+			if err != nil {
+				logEntry.Errorf("failed to parse revocation list: %v", err)
+				continue
+			}
+			data = newData
+			l.Update(doc)
+			logEntry.Infof("revocation list is updated")
+		}
+	}()
+	return nil
+}
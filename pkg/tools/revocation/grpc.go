@@ -0,0 +1,222 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package revocation
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/pkg/errors"
+	"github.com/spiffe/go-spiffe/v2/bundle/x509bundle"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/sdk/pkg/tools/spiffejwt"
+)
+
+const (
+	revocationServiceName = "networkservicemesh.revocation.Revocation"
+	watchMethod           = "Watch"
+)
+
+// signedDocument is the JWT claims of the published Document
+type signedDocument struct {
+	jwt.StandardClaims
+	// Version orders the published documents, so the older ones can't be replayed
+	Version    int64     `json:"version"`
+	Revocation *Document `json:"revocation"`
+}
+
+// watchService is implemented by Publisher
+type watchService interface {
+	watch(stream grpc.ServerStream) error
+}
+
+var revocationServiceDesc = grpc.ServiceDesc{
+	ServiceName: revocationServiceName,
+	HandlerType: (*watchService)(nil),
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    watchMethod,
+			Handler:       watchHandler,
+			ServerStreams: true,
+		},
+	},
+}
+
+func watchHandler(srv interface{}, stream grpc.ServerStream) error {
+	if err := stream.RecvMsg(new(empty.Empty)); err != nil {
+		return err
+	}
+	return srv.(watchService).watch(stream)
+}
+
+// Publisher streams the last published revocation list to the clients of Revocation gRPC service watching it with
+// List.Watch
+type Publisher struct {
+	mutex    sync.Mutex
+	payload  string
+	watchers map[chan string]struct{}
+}
+
+// NewPublisher creates Publisher, it should be registered on grpc.Server with Register
+func NewPublisher() *Publisher {
+	return &Publisher{
+		watchers: make(map[chan string]struct{}),
+	}
+}
+
+// Register registers Revocation gRPC service of p on server
+func (p *Publisher) Register(server *grpc.Server) {
+	server.RegisterService(&revocationServiceDesc, p)
+}
+
+// Publish signs doc by the X509-SVID from source and sends it to all the watching clients, the clients starting to
+// watch later get the last published one
+func (p *Publisher) Publish(doc *Document, source x509svid.Source) error {
+	svid, err := source.GetX509SVID()
+	if err != nil {
+		return err
+	}
+	payload, err := spiffejwt.Sign(svid, &signedDocument{
+		StandardClaims: jwt.StandardClaims{
+			Subject:  svid.ID.String(),
+			IssuedAt: time.Now().Unix(),
+		},
+		Version:    time.Now().UnixNano(),
+		Revocation: doc,
+	})
+	if err != nil {
+		return err
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.payload = payload
+	for ch := range p.watchers {
+		// Only the last list matters, so the one not yet sent to a slow watcher is replaced
+		select {
+		case <-ch:
+		default:
+		}
+		ch <- payload
+	}
+	return nil
+}
+
+func (p *Publisher) watch(stream grpc.ServerStream) error {
+	ch := make(chan string, 1)
+
+	p.mutex.Lock()
+	if p.payload != "" {
+		ch <- p.payload
+	}
+	p.watchers[ch] = struct{}{}
+	p.mutex.Unlock()
+
+	defer func() {
+		p.mutex.Lock()
+		delete(p.watchers, ch)
+		p.mutex.Unlock()
+	}()
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case payload := <-ch:
+			if err := stream.SendMsg(&wrappers.StringValue{Value: payload}); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// Watch watches the revocation list published by Publisher served on cc and updates the list with it while ctx is not
+// done. The published list is used only if it is signed by the publisher X509-SVID verified by the bundle and is issued
+// after the last used one, otherwise it is logged and skipped. Failed watches are retried every retryPeriod.
+func (l *List) Watch(ctx context.Context, cc grpc.ClientConnInterface, bundle x509bundle.Source, publisher spiffeid.ID, retryPeriod time.Duration) {
+	go func() {
+		logEntry := log.Entry(ctx).WithField("revocation", revocationServiceName)
+		var version int64
+		for {
+			if err := l.watch(ctx, cc, bundle, publisher, &version); err != nil && ctx.Err() == nil {
+				logEntry.Errorf("failed to watch revocation list: %v", err)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(retryPeriod):
+			}
+		}
+	}()
+}
+
+func (l *List) watch(ctx context.Context, cc grpc.ClientConnInterface, bundle x509bundle.Source, publisher spiffeid.ID, version *int64) error {
+	stream, err := cc.NewStream(ctx, &revocationServiceDesc.Streams[0], "/"+revocationServiceName+"/"+watchMethod)
+	if err != nil {
+		return err
+	}
+	if err := stream.SendMsg(new(empty.Empty)); err != nil {
+		return err
+	}
+	if err := stream.CloseSend(); err != nil {
+		return err
+	}
+	logEntry := log.Entry(ctx).WithField("revocation", revocationServiceName)
+	for {
+		msg := new(wrappers.StringValue)
+		if err := stream.RecvMsg(msg); err != nil {
+			return err
+		}
+		claims, err := verify(msg.GetValue(), bundle, publisher)
+		if err != nil {
+			logEntry.Errorf("failed to verify revocation list: %v", err)
+			continue
+		}
+		if claims.Version <= *version {
+			if claims.Version < *version {
+				logEntry.Errorf("revocation list older than the used one is skipped")
+			}
+			continue
+		}
+		*version = claims.Version
+		l.Update(claims.Revocation)
+	}
+}
+
+func verify(payload string, bundle x509bundle.Source, publisher spiffeid.ID) (*signedDocument, error) {
+	claims := new(signedDocument)
+	id, err := spiffejwt.Verify(payload, bundle, claims)
+	if err != nil {
+		return nil, err
+	}
+	if id != publisher {
+		return nil, errors.Errorf("revocation list is signed by %v instead of %v", id, publisher)
+	}
+	if claims.Revocation == nil {
+		return nil, errors.New("no revocation list in the payload")
+	}
+	return claims, nil
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package revocation
+
+import (
+	"encoding/json"
+	"sync"
+)
+
+// Document is JSON representation of the revocation list
+type Document struct {
+	// SpiffeIDs are revoked SPIFFE IDs, all the tokens with these subjects are revoked
+	SpiffeIDs []string `json:"spiffe_ids"`
+	// TokenIDs are IDs (jti claim) of revoked tokens
+	TokenIDs []string `json:"token_ids"`
+}
+
+// Parse parses JSON Document from data
+func Parse(data []byte) (*Document, error) {
+	doc := new(Document)
+	if err := json.Unmarshal(data, doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// List is a thread safe list of revoked SPIFFE IDs and token IDs
+type List struct {
+	mutex     sync.RWMutex
+	spiffeIDs map[string]bool
+	tokenIDs  map[string]bool
+}
+
+// NewList creates an empty List
+func NewList() *List {
+	return &List{
+		spiffeIDs: make(map[string]bool),
+		tokenIDs:  make(map[string]bool),
+	}
+}
+
+// Update replaces the content of the list with doc
+func (l *List) Update(doc *Document) {
+	spiffeIDs := make(map[string]bool, len(doc.SpiffeIDs))
+	for _, id := range doc.SpiffeIDs {
+		spiffeIDs[id] = true
+	}
+	tokenIDs := make(map[string]bool, len(doc.TokenIDs))
+	for _, id := range doc.TokenIDs {
+		tokenIDs[id] = true
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.spiffeIDs = spiffeIDs
+	l.tokenIDs = tokenIDs
+}
+
+// IsRevoked returns true if spiffeID or tokenID is revoked, empty values are never revoked
+func (l *List) IsRevoked(spiffeID, tokenID string) bool {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+
+	return (spiffeID != "" && l.spiffeIDs[spiffeID]) || (tokenID != "" && l.tokenIDs[tokenID])
+}
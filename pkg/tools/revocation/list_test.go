@@ -0,0 +1,193 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package revocation_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spiffe/go-spiffe/v2/bundle/x509bundle"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/revocation"
+)
+
+const testPeriod = 10 * time.Millisecond
+
+func TestList_WatchFile(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	dir, err := ioutil.TempDir("", "revocation")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+	path := filepath.Join(dir, "revoked.json")
+	require.NoError(t, ioutil.WriteFile(path, []byte(`{"spiffe_ids": ["spiffe://test.com/nse"]}`), 0600))
+
+	list := revocation.NewList()
+	require.NoError(t, list.WatchFile(ctx, path, testPeriod))
+	require.True(t, list.IsRevoked("spiffe://test.com/nse", ""))
+	require.False(t, list.IsRevoked("spiffe://test.com/nsc", "token-1"))
+	require.False(t, list.IsRevoked("", ""))
+
+	require.NoError(t, ioutil.WriteFile(path, []byte(`{"token_ids": ["token-1"]}`), 0600))
+	require.Eventually(t, func() bool {
+		return list.IsRevoked("spiffe://test.com/nsc", "token-1")
+	}, time.Second, testPeriod)
+	require.False(t, list.IsRevoked("spiffe://test.com/nse", ""))
+
+	// the last loaded list is kept on invalid file
+	require.NoError(t, ioutil.WriteFile(path, []byte(`{`), 0600))
+	time.Sleep(testPeriod * 5)
+	require.True(t, list.IsRevoked("", "token-1"))
+
+	require.Error(t, revocation.NewList().WatchFile(ctx, filepath.Join(dir, "missing.json"), testPeriod))
+}
+
+func newCA(t *testing.T) (*x509.Certificate, crypto.Signer) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	certBytes, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(certBytes)
+	require.NoError(t, err)
+	return cert, key
+}
+
+func newSVID(t *testing.T, caCert *x509.Certificate, caKey crypto.Signer, id spiffeid.ID) *x509svid.SVID {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		URIs:         []*url.URL{id.URL()},
+	}
+	certBytes, err := x509.CreateCertificate(rand.Reader, template, caCert, &key.PublicKey, caKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(certBytes)
+	require.NoError(t, err)
+	return &x509svid.SVID{
+		ID:           id,
+		Certificates: []*x509.Certificate{cert},
+		PrivateKey:   key,
+	}
+}
+
+func serve(t *testing.T, publisher *revocation.Publisher) (cc *grpc.ClientConn, stop func()) {
+	server := grpc.NewServer()
+	publisher.Register(server)
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() { _ = server.Serve(listener) }()
+
+	cc, err = grpc.Dial(listener.Addr().String(), grpc.WithInsecure())
+	require.NoError(t, err)
+	return cc, func() {
+		_ = cc.Close()
+		server.Stop()
+	}
+}
+
+func TestList_Watch(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	caCert, caKey := newCA(t)
+	id := spiffeid.Must("test.com", "admin")
+	bundle := x509bundle.FromX509Authorities(id.TrustDomain(), []*x509.Certificate{caCert})
+	svid := newSVID(t, caCert, caKey, id)
+
+	publisher := revocation.NewPublisher()
+	cc, stop := serve(t, publisher)
+	defer stop()
+	require.NoError(t, publisher.Publish(&revocation.Document{
+		SpiffeIDs: []string{"spiffe://test.com/nse"},
+	}, svid))
+
+	list := revocation.NewList()
+	list.Watch(ctx, cc, bundle, id, testPeriod)
+	require.Eventually(t, func() bool {
+		return list.IsRevoked("spiffe://test.com/nse", "")
+	}, time.Second, testPeriod)
+
+	require.NoError(t, publisher.Publish(&revocation.Document{
+		TokenIDs: []string{"token-1"},
+	}, svid))
+	require.Eventually(t, func() bool {
+		return list.IsRevoked("", "token-1") && !list.IsRevoked("spiffe://test.com/nse", "")
+	}, time.Second, testPeriod)
+}
+
+func TestList_Watch_NotTrusted(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	caCert, caKey := newCA(t)
+	anotherCACert, anotherCAKey := newCA(t)
+	id := spiffeid.Must("test.com", "admin")
+	bundle := x509bundle.FromX509Authorities(id.TrustDomain(), []*x509.Certificate{caCert})
+
+	publisher := revocation.NewPublisher()
+	cc, stop := serve(t, publisher)
+	defer stop()
+	require.NoError(t, publisher.Publish(&revocation.Document{
+		SpiffeIDs: []string{"spiffe://test.com/nse"},
+	}, newSVID(t, caCert, caKey, id)))
+
+	list := revocation.NewList()
+	list.Watch(ctx, cc, bundle, id, testPeriod)
+	require.Eventually(t, func() bool {
+		return list.IsRevoked("spiffe://test.com/nse", "")
+	}, time.Second, testPeriod)
+
+	// Neither the list signed by another workload nor the one not verified by the bundle are used
+	require.NoError(t, publisher.Publish(&revocation.Document{},
+		newSVID(t, caCert, caKey, spiffeid.Must("test.com", "intruder"))))
+	require.NoError(t, publisher.Publish(&revocation.Document{},
+		newSVID(t, anotherCACert, anotherCAKey, id)))
+	<-time.After(testPeriod * 5)
+	require.True(t, list.IsRevoked("spiffe://test.com/nse", ""))
+}
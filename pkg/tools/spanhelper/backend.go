@@ -0,0 +1,95 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spanhelper
+
+import (
+	"io"
+	"os"
+	"strings"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/sirupsen/logrus"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/jaeger"
+	"github.com/networkservicemesh/sdk/pkg/tools/otlp"
+)
+
+// Tracing backends
+const (
+	// JaegerBackend reports spans to Jaeger agent configured with Jaeger environment variables
+	JaegerBackend = "jaeger"
+	// OTLPBackend exports spans to OpenTelemetry collector with OTLP/HTTP and propagates W3C trace context
+	OTLPBackend = "otlp"
+	// NoopBackend disables tracing
+	NoopBackend = "noop"
+)
+
+const (
+	tracerBackendEnv     = "TRACER_BACKEND"
+	tracerBackendDefault = JaegerBackend
+	otlpEndpointEnv      = "OTEL_EXPORTER_OTLP_ENDPOINT"
+)
+
+// Backend returns tracing backend set by TRACER_BACKEND environment variable, JaegerBackend is used by default
+func Backend() string {
+	if backend := strings.ToLower(os.Getenv(tracerBackendEnv)); backend != "" {
+		return backend
+	}
+	return tracerBackendDefault
+}
+
+// IsTracingEnabled returns true if tracing is enabled with TRACER_ENABLED and TRACER_BACKEND is not NoopBackend
+func IsTracingEnabled() bool {
+	return jaeger.IsOpentracingEnabled() && Backend() != NoopBackend
+}
+
+type emptyCloser struct{}
+
+func (*emptyCloser) Close() error {
+	return nil
+}
+
+// InitTracing initializes the global tracer of Backend() for the service, returned io.Closer should be closed to flush
+// the remaining spans. JaegerBackend uses jaeger.InitJaeger, OTLPBackend exports spans to OTEL_EXPORTER_OTLP_ENDPOINT
+// ("http://localhost:4318" by default), NoopBackend disables tracing.
+func InitTracing(service string) io.Closer {
+	if !IsTracingEnabled() {
+		return &emptyCloser{}
+	}
+	switch backend := Backend(); backend {
+	case JaegerBackend:
+		closer, err := jaeger.InitJaeger(service)
+		if err != nil {
+			logrus.Errorf("%v, tracing is disabled", err)
+		}
+		return closer
+	case OTLPBackend:
+		if opentracing.IsGlobalTracerRegistered() {
+			logrus.Warningf("global opentracer is already initialized")
+		}
+		var options []otlp.Option
+		if endpoint := os.Getenv(otlpEndpointEnv); endpoint != "" {
+			options = append(options, otlp.WithEndpoint(endpoint))
+		}
+		tracer := otlp.NewTracer(service, options...)
+		opentracing.SetGlobalTracer(tracer)
+		return tracer
+	default:
+		logrus.Errorf("unknown tracing backend %s, tracing is disabled", backend)
+		return &emptyCloser{}
+	}
+}
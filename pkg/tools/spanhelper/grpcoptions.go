@@ -23,13 +23,11 @@ import (
 	"github.com/opentracing/opentracing-go"
 	"google.golang.org/grpc"
 	"google.golang.org/protobuf/proto"
-
-	"github.com/networkservicemesh/sdk/pkg/tools/jaeger"
 )
 
 // WithTracing - returns array of grpc.ServerOption that should be passed to grpc.Dial to enable opentracing
 func WithTracing() []grpc.ServerOption {
-	if jaeger.IsOpentracingEnabled() {
+	if IsTracingEnabled() {
 		interceptor := func(
 			ctx context.Context,
 			req interface{},
@@ -52,7 +50,7 @@ func WithTracing() []grpc.ServerOption {
 
 // WithTracingDial returns array of grpc.DialOption that should be passed to grpc.Dial to enable opentracing
 func WithTracingDial() []grpc.DialOption {
-	if jaeger.IsOpentracingEnabled() {
+	if IsTracingEnabled() {
 		interceptor := func(
 			ctx context.Context,
 			method string,
@@ -29,8 +29,6 @@ import (
 	"github.com/opentracing/opentracing-go"
 	"github.com/opentracing/opentracing-go/log"
 	"github.com/sirupsen/logrus"
-
-	"github.com/networkservicemesh/sdk/pkg/tools/jaeger"
 )
 
 type spanHelperKeyType string
@@ -193,7 +191,7 @@ func (s *spanHelper) Context() context.Context {
 
 // FromContext - return span helper from context and if opentracing is enabled start new span
 func FromContext(ctx context.Context, operation string) (result SpanHelper) {
-	if jaeger.IsOpentracingEnabled() {
+	if IsTracingEnabled() {
 		newSpan, newCtx := opentracing.StartSpanFromContext(ctx, operation)
 		result = NewSpanHelper(newCtx, newSpan, operation)
 	} else {
@@ -211,7 +209,7 @@ func printStart(result SpanHelper, operation string) {
 
 // GetSpanHelper - construct a span helper object from current context span
 func GetSpanHelper(ctx context.Context) SpanHelper {
-	if jaeger.IsOpentracingEnabled() {
+	if IsTracingEnabled() {
 		span := opentracing.SpanFromContext(ctx)
 		return NewSpanHelper(ctx, span, "")
 	}
@@ -231,7 +229,7 @@ func CopySpan(ctx context.Context, spanContext SpanHelper, operation string) Spa
 // WithSpan - construct span helper object with ctx and copy spanid from span
 // Will start new operation on span
 func WithSpan(ctx context.Context, span opentracing.Span, operation string) (result SpanHelper) {
-	if jaeger.IsOpentracingEnabled() && span != nil {
+	if IsTracingEnabled() && span != nil {
 		ctx = opentracing.ContextWithSpan(ctx, span)
 		newSpan, newCtx := opentracing.StartSpanFromContext(ctx, operation)
 		result = NewSpanHelper(newCtx, newSpan, operation)
@@ -0,0 +1,56 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spiffecreds
+
+import (
+	"crypto/x509"
+
+	"github.com/pkg/errors"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/go-spiffe/v2/spiffetls/tlsconfig"
+)
+
+// AuthorizeIDs returns tlsconfig.Authorizer accepting only peers with one of SPIFFE IDs ids
+func AuthorizeIDs(ids ...string) (tlsconfig.Authorizer, error) {
+	allowed := make([]spiffeid.ID, 0, len(ids))
+	for _, id := range ids {
+		spiffeID, err := spiffeid.FromString(id)
+		if err != nil {
+			return nil, err
+		}
+		allowed = append(allowed, spiffeID)
+	}
+	return tlsconfig.AuthorizeOneOf(allowed...), nil
+}
+
+// AuthorizeTrustDomains returns tlsconfig.Authorizer accepting only peers from one of trust domains trustDomains
+func AuthorizeTrustDomains(trustDomains ...string) (tlsconfig.Authorizer, error) {
+	allowed := make(map[spiffeid.TrustDomain]bool, len(trustDomains))
+	for _, td := range trustDomains {
+		trustDomain, err := spiffeid.TrustDomainFromString(td)
+		if err != nil {
+			return nil, err
+		}
+		allowed[trustDomain] = true
+	}
+	return func(id spiffeid.ID, _ [][]*x509.Certificate) error {
+		if !allowed[id.TrustDomain()] {
+			return errors.Errorf("unexpected trust domain %q", id.TrustDomain())
+		}
+		return nil
+	}, nil
+}
@@ -0,0 +1,57 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spiffecreds
+
+import (
+	"crypto/tls"
+
+	"github.com/spiffe/go-spiffe/v2/bundle/x509bundle"
+	"github.com/spiffe/go-spiffe/v2/spiffetls/tlsconfig"
+	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// ServerCredentials returns mTLS server credentials presenting X509-SVID from svid and accepting clients with
+// X509-SVIDs verified by bundle and authorized by authorizer. The sources are read on every handshake, so rotated
+// SVIDs and bundles are used by the new connections without recreating the credentials.
+func ServerCredentials(svid x509svid.Source, bundle x509bundle.Source, authorizer tlsconfig.Authorizer) credentials.TransportCredentials {
+	return credentials.NewTLS(ServerTLSConfig(svid, bundle, authorizer))
+}
+
+// ServerTLSConfig returns mTLS server *tls.Config of ServerCredentials, e.g. for admin.ListenAndServe, so the HTTP
+// endpoints accept the same peers as the gRPC API
+func ServerTLSConfig(svid x509svid.Source, bundle x509bundle.Source, authorizer tlsconfig.Authorizer) *tls.Config {
+	return tlsconfig.MTLSServerConfig(svid, bundle, authorizer)
+}
+
+// ClientCredentials returns mTLS client credentials presenting X509-SVID from svid and accepting servers with
+// X509-SVIDs verified by bundle and authorized by authorizer. The sources are read on every handshake.
+func ClientCredentials(svid x509svid.Source, bundle x509bundle.Source, authorizer tlsconfig.Authorizer) credentials.TransportCredentials {
+	return credentials.NewTLS(tlsconfig.MTLSClientConfig(svid, bundle, authorizer))
+}
+
+// ServerOption returns grpc.ServerOption with ServerCredentials, e.g. for the server passed to endpoint.Register and
+// grpcutils.ListenAndServe
+func ServerOption(svid x509svid.Source, bundle x509bundle.Source, authorizer tlsconfig.Authorizer) grpc.ServerOption {
+	return grpc.Creds(ServerCredentials(svid, bundle, authorizer))
+}
+
+// DialOption returns grpc.DialOption with ClientCredentials, e.g. for connect.NewServer
+func DialOption(svid x509svid.Source, bundle x509bundle.Source, authorizer tlsconfig.Authorizer) grpc.DialOption {
+	return grpc.WithTransportCredentials(ClientCredentials(svid, bundle, authorizer))
+}
@@ -0,0 +1,140 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spiffecreds_test
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/go-spiffe/v2/spiffetls/tlsconfig"
+	"github.com/spiffe/go-spiffe/v2/workloadapi"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/spiffecreds"
+	"github.com/networkservicemesh/sdk/pkg/tools/spire/inprocess"
+)
+
+func startHealthServer(t *testing.T, option grpc.ServerOption) (string, func()) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpc.NewServer(option)
+	grpc_health_v1.RegisterHealthServer(server, health.NewServer())
+	go func() { _ = server.Serve(listener) }()
+	return listener.Addr().String(), server.Stop
+}
+
+func check(ctx context.Context, target string, option grpc.DialOption) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	cc, err := grpc.DialContext(ctx, target, option, grpc.WithBlock())
+	if err != nil {
+		return err
+	}
+	defer func() { _ = cc.Close() }()
+	_, err = grpc_health_v1.NewHealthClient(cc).Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+	return err
+}
+
+func TestCredentials_Authorizers(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	s, err := inprocess.Start(ctx,
+		inprocess.WithEntry("spiffe://example.org/nsmgr", fmt.Sprintf("unix:uid:%d", os.Getuid())))
+	require.NoError(t, err)
+	source, err := workloadapi.NewX509Source(ctx, workloadapi.WithClientOptions(workloadapi.WithAddr(s.Addr())))
+	require.NoError(t, err)
+	defer func() { _ = source.Close() }()
+
+	sameDomain, err := spiffecreds.AuthorizeTrustDomains("example.org")
+	require.NoError(t, err)
+	otherDomain, err := spiffecreds.AuthorizeTrustDomains("other.org")
+	require.NoError(t, err)
+	nsmgr, err := spiffecreds.AuthorizeIDs("spiffe://example.org/nsmgr")
+	require.NoError(t, err)
+	nse, err := spiffecreds.AuthorizeIDs("spiffe://example.org/nse")
+	require.NoError(t, err)
+	_, err = spiffecreds.AuthorizeIDs("not a spiffe id")
+	require.Error(t, err)
+
+	target, stop := startHealthServer(t, spiffecreds.ServerOption(source, source, sameDomain))
+	defer stop()
+
+	require.NoError(t, check(ctx, target, spiffecreds.DialOption(source, source, nsmgr)))
+	require.Error(t, check(ctx, target, spiffecreds.DialOption(source, source, nse)))
+	require.Error(t, check(ctx, target, spiffecreds.DialOption(source, source, otherDomain)))
+
+	rejecting, stop := startHealthServer(t, spiffecreds.ServerOption(source, source, otherDomain))
+	defer stop()
+	require.Error(t, check(ctx, rejecting, spiffecreds.DialOption(source, source, nsmgr)))
+}
+
+func TestCredentials_Rotation(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	s, err := inprocess.Start(ctx,
+		inprocess.WithEntry("spiffe://example.org/nsmgr", fmt.Sprintf("unix:uid:%d", os.Getuid())))
+	require.NoError(t, err)
+	source, err := workloadapi.NewX509Source(ctx, workloadapi.WithClientOptions(workloadapi.WithAddr(s.Addr())))
+	require.NoError(t, err)
+	defer func() { _ = source.Close() }()
+
+	target, stop := startHealthServer(t, spiffecreds.ServerOption(source, source, tlsconfig.AuthorizeAny()))
+	defer stop()
+
+	var mutex sync.Mutex
+	var serials []*big.Int
+	recording := func(_ spiffeid.ID, chains [][]*x509.Certificate) error {
+		mutex.Lock()
+		defer mutex.Unlock()
+		serials = append(serials, chains[0][0].SerialNumber)
+		return nil
+	}
+	dialOption := spiffecreds.DialOption(source, source, recording)
+
+	require.NoError(t, check(ctx, target, dialOption))
+
+	svid, err := source.GetX509SVID()
+	require.NoError(t, err)
+	require.NoError(t, s.Rotate())
+	require.Eventually(t, func() bool {
+		rotated, err := source.GetX509SVID()
+		return err == nil && rotated.Certificates[0].SerialNumber.Cmp(svid.Certificates[0].SerialNumber) != 0
+	}, 5*time.Second, 10*time.Millisecond)
+
+	require.NoError(t, check(ctx, target, dialOption))
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	require.Len(t, serials, 2)
+	require.NotEqual(t, 0, serials[0].Cmp(serials[1]))
+}
@@ -0,0 +1,48 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spiffejwt
+
+import (
+	"google.golang.org/grpc/credentials"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/peerid"
+)
+
+// AudienceFunc returns the audience of the token generated for the peer with authInfo, empty audience is not set
+type AudienceFunc func(authInfo credentials.AuthInfo) (string, error)
+
+// PeerAudience returns AudienceFunc setting the audience to SPIFFE ID of the peer. If the peer has no certificate, e.g.
+// is not a TLS peer, the audience is set to fallback. It fails if the peer certificate is not an X509-SVID.
+func PeerAudience(fallback string) AudienceFunc {
+	return func(authInfo credentials.AuthInfo) (string, error) {
+		if peerid.Certificate(authInfo) == nil {
+			return fallback, nil
+		}
+		id, err := peerid.FromAuthInfo(authInfo)
+		if err != nil {
+			return "", err
+		}
+		return id.String(), nil
+	}
+}
+
+// StaticAudience returns AudienceFunc setting the audience to audience for all peers
+func StaticAudience(audience string) AudienceFunc {
+	return func(credentials.AuthInfo) (string, error) {
+		return audience, nil
+	}
+}
@@ -14,5 +14,5 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-// Package spiffejwt provides a token.GeneratorFunc for spiffe jwt tokens signed by x509vids
+// Package spiffejwt provides a token.GeneratorFunc for spiffe jwt tokens signed by x509vids or by static keys
 package spiffejwt
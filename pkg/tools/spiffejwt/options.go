@@ -0,0 +1,41 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spiffejwt
+
+type config struct {
+	audience AudienceFunc
+}
+
+// Option is an option pattern for TokenGeneratorFunc and StaticKeyTokenGeneratorFunc
+type Option func(c *config)
+
+// WithAudience sets the audience strategy of the generated tokens. Default is PeerAudience("").
+func WithAudience(audience AudienceFunc) Option {
+	return func(c *config) {
+		c.audience = audience
+	}
+}
+
+func newConfig(options []Option) *config {
+	c := &config{
+		audience: PeerAudience(""),
+	}
+	for _, o := range options {
+		o(c)
+	}
+	return c
+}
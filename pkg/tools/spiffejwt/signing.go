@@ -0,0 +1,111 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spiffejwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/pkg/errors"
+)
+
+// SigningMethodEdDSA is EdDSA signing method with Ed25519 keys, jwt-go doesn't provide it. It is registered in jwt-go,
+// so EdDSA tokens may be parsed with jwt.Parse.
+var SigningMethodEdDSA = registerSigningMethod(&signingMethodEdDSA{})
+
+func registerSigningMethod(method jwt.SigningMethod) jwt.SigningMethod {
+	jwt.RegisterSigningMethod(method.Alg(), func() jwt.SigningMethod {
+		return method
+	})
+	return method
+}
+
+type signingMethodEdDSA struct{}
+
+func (m *signingMethodEdDSA) Alg() string {
+	return "EdDSA"
+}
+
+func (m *signingMethodEdDSA) Verify(signingString, signature string, key interface{}) error {
+	publicKey, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return jwt.ErrInvalidKeyType
+	}
+	sig, err := jwt.DecodeSegment(signature)
+	if err != nil {
+		return err
+	}
+	if !ed25519.Verify(publicKey, []byte(signingString), sig) {
+		return errors.New("EdDSA verification failed")
+	}
+	return nil
+}
+
+func (m *signingMethodEdDSA) Sign(signingString string, key interface{}) (string, error) {
+	privateKey, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return "", jwt.ErrInvalidKeyType
+	}
+	sig, err := privateKey.Sign(rand.Reader, []byte(signingString), crypto.Hash(0))
+	if err != nil {
+		return "", err
+	}
+	return jwt.EncodeSegment(sig), nil
+}
+
+// signingMethod returns JWT signing method for the private key: ES256/ES384/ES512 for ECDSA keys depending on the
+// curve, RS256 for RSA keys and EdDSA for Ed25519 keys
+func signingMethod(key crypto.PrivateKey) (jwt.SigningMethod, error) {
+	switch k := key.(type) {
+	case *ecdsa.PrivateKey:
+		switch k.Curve {
+		case elliptic.P256():
+			return jwt.SigningMethodES256, nil
+		case elliptic.P384():
+			return jwt.SigningMethodES384, nil
+		case elliptic.P521():
+			return jwt.SigningMethodES512, nil
+		}
+		return nil, errors.Errorf("unsupported ECDSA curve %v", k.Curve.Params().Name)
+	case *rsa.PrivateKey:
+		return jwt.SigningMethodRS256, nil
+	case ed25519.PrivateKey:
+		return SigningMethodEdDSA, nil
+	}
+	return nil, errors.Errorf("unsupported private key type %T", key)
+}
+
+// sign signs claims with the key, headers are added to the token header
+func sign(key crypto.PrivateKey, claims jwt.Claims, headers map[string]interface{}) (string, error) {
+	if k, ok := key.(*ed25519.PrivateKey); ok {
+		key = *k
+	}
+	method, err := signingMethod(key)
+	if err != nil {
+		return "", err
+	}
+	tok := jwt.NewWithClaims(method, claims)
+	for k, v := range headers {
+		tok.Header[k] = v
+	}
+	return tok.SignedString(key)
+}
@@ -0,0 +1,94 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spiffejwt
+
+import (
+	"crypto"
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/token"
+)
+
+// StaticKeyTokenGeneratorFunc - creates a token.GeneratorFunc that creates JWT tokens with the subject signed by the
+//                               private key from the PEM keyFile, for environments without SPIRE. PKCS#8, EC and
+//                               PKCS#1 RSA keys are supported, the signing method is chosen by the key type.
+func StaticKeyTokenGeneratorFunc(keyFile string, subject spiffeid.ID, maxTokenLifeTime time.Duration, options ...Option) (token.GeneratorFunc, error) {
+	b, err := ioutil.ReadFile(keyFile)
+	if err != nil {
+		return nil, err
+	}
+	key, err := ParsePrivateKey(b)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse %v", keyFile)
+	}
+	c := newConfig(options)
+	return func(authInfo credentials.AuthInfo) (string, time.Time, error) {
+		expireTime := expireTimeFor(authInfo, maxTokenLifeTime)
+		audience, err := c.audience(authInfo)
+		if err != nil {
+			return "", time.Time{}, errors.Wrap(err, "Error creating Token")
+		}
+		claims := jwt.StandardClaims{
+			Subject:   subject.String(),
+			Audience:  audience,
+			ExpiresAt: expireTime.Unix(),
+			// jti lets the token be revoked individually
+			Id: uuid.New().String(),
+		}
+		signed, err := sign(key, claims, nil)
+		return signed, expireTime, err
+	}, nil
+}
+
+// ParsePrivateKey parses the first supported private key from PEM encoded data
+func ParsePrivateKey(data []byte) (crypto.PrivateKey, error) {
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return nil, errors.New("no private key found")
+		}
+		var key crypto.PrivateKey
+		var err error
+		switch block.Type {
+		case "PRIVATE KEY":
+			key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+		case "EC PRIVATE KEY":
+			key, err = x509.ParseECPrivateKey(block.Bytes)
+		case "RSA PRIVATE KEY":
+			key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if _, err := signingMethod(key); err != nil {
+			return nil, err
+		}
+		return key, nil
+	}
+}
@@ -17,51 +17,113 @@
 package spiffejwt
 
 import (
+	"crypto/x509"
+	"encoding/base64"
 	"time"
 
 	"github.com/dgrijalva/jwt-go"
+	"github.com/google/uuid"
 	"github.com/pkg/errors"
+	"github.com/spiffe/go-spiffe/v2/bundle/x509bundle"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
 	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
 	"google.golang.org/grpc/credentials"
 
+	"github.com/networkservicemesh/sdk/pkg/tools/peerid"
 	"github.com/networkservicemesh/sdk/pkg/tools/token"
 )
 
 // TokenGeneratorFunc - creates a token.TokenGeneratorFunc that creates spiffe JWT tokens from the cert returned by getCert()
-func TokenGeneratorFunc(source x509svid.Source, maxTokenLifeTime time.Duration) token.GeneratorFunc {
+//                      Tokens are signed with the X509-SVID private key, the signing method is chosen by the key type:
+//                      ES256/ES384/ES512 for ECDSA, RS256 for RSA and EdDSA for Ed25519.
+func TokenGeneratorFunc(source x509svid.Source, maxTokenLifeTime time.Duration, options ...Option) token.GeneratorFunc {
+	c := newConfig(options)
 	return func(authInfo credentials.AuthInfo) (string, time.Time, error) {
 		ownSVID, err := source.GetX509SVID()
 		if err != nil {
 			return "", time.Time{}, errors.Wrap(err, "Error creating Token")
 		}
 
-		expireTime := time.Now().Add(maxTokenLifeTime)
+		expireTime := expireTimeFor(authInfo, maxTokenLifeTime)
 		if ownSVID.Certificates[0].NotAfter.Before(expireTime) {
 			expireTime = ownSVID.Certificates[0].NotAfter
 		}
+		audience, err := c.audience(authInfo)
 		if err != nil {
 			return "", time.Time{}, errors.Wrap(err, "Error creating Token")
 		}
 		claims := jwt.StandardClaims{
 			Subject:   ownSVID.ID.String(),
+			Audience:  audience,
 			ExpiresAt: expireTime.Unix(),
+			// jti lets the token be revoked individually
+			Id: uuid.New().String(),
 		}
-		if authInfo != nil {
-			if tlsInfo, ok := authInfo.(credentials.TLSInfo); ok {
-				if len(tlsInfo.State.PeerCertificates) > 0 {
-					peerCert := tlsInfo.State.PeerCertificates[0]
-					peerSpiffeID, err2 := x509svid.IDFromCert(peerCert)
-					if err2 != nil {
-						return "", time.Time{}, err2
-					}
-					if peerCert.NotAfter.Before(expireTime) {
-						expireTime = peerCert.NotAfter
-					}
-					claims.Audience = peerSpiffeID.String()
-				}
-			}
+		signed, err := Sign(ownSVID, claims)
+		return signed, expireTime, err
+	}
+}
+
+// Sign signs claims with the X509-SVID private key, the X509-SVID certificate chain is put into the "x5c" token header
+// so the receivers may verify the token against the trust bundle with Verify
+func Sign(svid *x509svid.SVID, claims jwt.Claims) (string, error) {
+	var x5c []string
+	for _, cert := range svid.Certificates {
+		x5c = append(x5c, base64.StdEncoding.EncodeToString(cert.Raw))
+	}
+	return sign(svid.PrivateKey, claims, map[string]interface{}{"x5c": x5c})
+}
+
+// Verify parses the token signed by Sign into claims. The "x5c" certificate chain is verified by the bundle, the token
+// signature is verified by the leaf certificate and the standard claims (e.g. expiration) are validated. The SPIFFE
+// ID of the signer is returned.
+func Verify(token string, bundle x509bundle.Source, claims jwt.Claims) (spiffeid.ID, error) {
+	var id spiffeid.ID
+	_, err := jwt.ParseWithClaims(token, claims, func(tok *jwt.Token) (interface{}, error) {
+		certs, err := x5cCertificates(tok)
+		if err != nil {
+			return nil, err
+		}
+		if id, _, err = x509svid.Verify(certs, bundle); err != nil {
+			return nil, err
+		}
+		return certs[0].PublicKey, nil
+	})
+	if err != nil {
+		return spiffeid.ID{}, err
+	}
+	return id, nil
+}
+
+func x5cCertificates(tok *jwt.Token) ([]*x509.Certificate, error) {
+	x5c, ok := tok.Header["x5c"].([]interface{})
+	if !ok || len(x5c) == 0 {
+		return nil, errors.New("no x5c token header")
+	}
+	var certs []*x509.Certificate
+	for _, v := range x5c {
+		s, ok := v.(string)
+		if !ok {
+			return nil, errors.New("invalid x5c token header")
+		}
+		der, err := base64.StdEncoding.DecodeString(s)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid x5c token header")
 		}
-		tok, err := jwt.NewWithClaims(jwt.SigningMethodES256, claims).SignedString(ownSVID.PrivateKey)
-		return tok, expireTime, err
+		cert, err := x509.ParseCertificate(der)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid x5c token header")
+		}
+		certs = append(certs, cert)
+	}
+	return certs, nil
+}
+
+// expireTimeFor returns now + maxTokenLifeTime limited by the expiration of the peer certificate
+func expireTimeFor(authInfo credentials.AuthInfo, maxTokenLifeTime time.Duration) time.Time {
+	expireTime := time.Now().Add(maxTokenLifeTime)
+	if peerCert := peerid.Certificate(authInfo); peerCert != nil && peerCert.NotAfter.Before(expireTime) {
+		expireTime = peerCert.NotAfter
 	}
+	return expireTime
 }
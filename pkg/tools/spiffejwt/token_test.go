@@ -0,0 +1,155 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spiffejwt_test
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/spiffejwt"
+)
+
+func generateSVID(t *testing.T, id string, key crypto.Signer) *x509svid.SVID {
+	spiffeID, err := spiffeid.FromString(id)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		NotBefore:    time.Now().Add(-time.Minute),
+		NotAfter:     time.Now().Add(time.Hour),
+		URIs:         []*url.URL{spiffeID.URL()},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return &x509svid.SVID{
+		ID:           spiffeID,
+		Certificates: []*x509.Certificate{cert},
+		PrivateKey:   key,
+	}
+}
+
+func parse(t *testing.T, tok string, publicKey crypto.PublicKey) *jwt.StandardClaims {
+	claims := new(jwt.StandardClaims)
+	_, err := jwt.ParseWithClaims(tok, claims, func(*jwt.Token) (interface{}, error) {
+		return publicKey, nil
+	})
+	require.NoError(t, err)
+	return claims
+}
+
+func TestTokenGeneratorFunc_KeyTypes(t *testing.T) {
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	_, ed25519Key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	for alg, key := range map[string]crypto.Signer{
+		"ES384": ecdsaKey,
+		"RS256": rsaKey,
+		"EdDSA": ed25519Key,
+	} {
+		svid := generateSVID(t, "spiffe://test.com/nsc", key)
+		tok, expireTime, err := spiffejwt.TokenGeneratorFunc(svid, time.Minute)(nil)
+		require.NoError(t, err, alg)
+		require.True(t, expireTime.Before(time.Now().Add(time.Minute+time.Second)))
+
+		claims := parse(t, tok, key.Public())
+		require.Equal(t, "spiffe://test.com/nsc", claims.Subject)
+		require.Empty(t, claims.Audience)
+
+		parsed, _, err := new(jwt.Parser).ParseUnverified(tok, new(jwt.StandardClaims))
+		require.NoError(t, err)
+		require.Equal(t, alg, parsed.Method.Alg())
+		require.Len(t, parsed.Header["x5c"], 1)
+	}
+}
+
+func TestTokenGeneratorFunc_Audience(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	svid := generateSVID(t, "spiffe://test.com/nsc", key)
+	peerSVID := generateSVID(t, "spiffe://test.com/nsmgr", key)
+	tlsInfo := credentials.TLSInfo{
+		State: tls.ConnectionState{PeerCertificates: peerSVID.Certificates},
+	}
+
+	for _, authInfo := range []credentials.AuthInfo{tlsInfo, &tlsInfo} {
+		tok, _, err := spiffejwt.TokenGeneratorFunc(svid, time.Hour)(authInfo)
+		require.NoError(t, err)
+		require.Equal(t, "spiffe://test.com/nsmgr", parse(t, tok, key.Public()).Audience)
+	}
+
+	generator := spiffejwt.TokenGeneratorFunc(svid, time.Hour, spiffejwt.WithAudience(spiffejwt.PeerAudience("spiffe://test.com/any")))
+	tok, _, err := generator(nil)
+	require.NoError(t, err)
+	require.Equal(t, "spiffe://test.com/any", parse(t, tok, key.Public()).Audience)
+
+	generator = spiffejwt.TokenGeneratorFunc(svid, time.Hour, spiffejwt.WithAudience(spiffejwt.StaticAudience("nsm")))
+	tok, _, err = generator(&tlsInfo)
+	require.NoError(t, err)
+	require.Equal(t, "nsm", parse(t, tok, key.Public()).Audience)
+}
+
+func TestStaticKeyTokenGeneratorFunc(t *testing.T) {
+	dir, err := ioutil.TempDir("", "spiffejwt")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	keyFile := filepath.Join(dir, "key.pem")
+	require.NoError(t, ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0600))
+
+	id, err := spiffeid.FromString("spiffe://test.com/nsc")
+	require.NoError(t, err)
+	generator, err := spiffejwt.StaticKeyTokenGeneratorFunc(keyFile, id, time.Hour, spiffejwt.WithAudience(spiffejwt.StaticAudience("nsm")))
+	require.NoError(t, err)
+
+	tok, _, err := generator(nil)
+	require.NoError(t, err)
+	claims := parse(t, tok, key.Public())
+	require.Equal(t, "spiffe://test.com/nsc", claims.Subject)
+	require.Equal(t, "nsm", claims.Audience)
+
+	_, err = spiffejwt.StaticKeyTokenGeneratorFunc(filepath.Join(dir, "missing.pem"), id, time.Hour)
+	require.Error(t, err)
+}
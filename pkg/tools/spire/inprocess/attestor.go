@@ -0,0 +1,87 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inprocess
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+const authType = "inprocess-spire-peer"
+
+// callerInfo is credentials.AuthInfo of the Workload API caller
+type callerInfo struct {
+	uid, gid, pid int
+	err           error
+}
+
+func (c *callerInfo) AuthType() string {
+	return authType
+}
+
+// selectors returns unix selectors of the caller
+func (c *callerInfo) selectors() []string {
+	return []string{
+		fmt.Sprintf("unix:uid:%d", c.uid),
+		fmt.Sprintf("unix:gid:%d", c.gid),
+		fmt.Sprintf("unix:pid:%d", c.pid),
+	}
+}
+
+func callerFromContext(ctx context.Context) (*callerInfo, error) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil, errors.New("no peer in context")
+	}
+	caller, ok := p.AuthInfo.(*callerInfo)
+	if !ok {
+		return nil, errors.Errorf("unexpected auth info %T", p.AuthInfo)
+	}
+	if caller.err != nil {
+		return nil, caller.err
+	}
+	return caller, nil
+}
+
+// peerCredentials is credentials.TransportCredentials attesting the caller by its unix socket peer credentials
+type peerCredentials struct{}
+
+func (c *peerCredentials) ClientHandshake(_ context.Context, _ string, conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	return conn, &callerInfo{}, nil
+}
+
+func (c *peerCredentials) ServerHandshake(conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	// attestation errors are reported on the calls rather than on the handshake, so the caller gets a clear status
+	return conn, attest(conn), nil
+}
+
+func (c *peerCredentials) Info() credentials.ProtocolInfo {
+	return credentials.ProtocolInfo{SecurityProtocol: authType}
+}
+
+func (c *peerCredentials) Clone() credentials.TransportCredentials {
+	return &peerCredentials{}
+}
+
+func (c *peerCredentials) OverrideServerName(string) error {
+	return nil
+}
@@ -0,0 +1,54 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package inprocess
+
+import (
+	"net"
+
+	"github.com/pkg/errors"
+	"golang.org/x/sys/unix"
+)
+
+// attest reads the peer credentials of the unix socket conn
+func attest(conn net.Conn) *callerInfo {
+	unixConn, ok := conn.(*net.UnixConn)
+	if !ok {
+		return &callerInfo{err: errors.Errorf("unexpected connection type %T", conn)}
+	}
+	rawConn, err := unixConn.SyscallConn()
+	if err != nil {
+		return &callerInfo{err: err}
+	}
+	var cred *unix.Ucred
+	var credErr error
+	if err := rawConn.Control(func(fd uintptr) {
+		cred, credErr = unix.GetsockoptUcred(int(fd), unix.SOL_SOCKET, unix.SO_PEERCRED)
+	}); err != nil {
+		return &callerInfo{err: err}
+	}
+	if credErr != nil {
+		return &callerInfo{err: errors.Wrap(credErr, "failed to get peer credentials")}
+	}
+	return &callerInfo{
+		uid: int(cred.Uid),
+		gid: int(cred.Gid),
+		pid: int(cred.Pid),
+	}
+}
@@ -0,0 +1,160 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inprocess
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"math/big"
+	"net/url"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/pkg/errors"
+	"github.com/spiffe/go-spiffe/v2/bundle/jwtbundle"
+	"github.com/spiffe/go-spiffe/v2/bundle/x509bundle"
+	"github.com/spiffe/go-spiffe/v2/proto/spiffe/workload"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+)
+
+// authority is an in-memory CA of the trust domain
+type authority struct {
+	trustDomain spiffeid.TrustDomain
+	caCert      *x509.Certificate
+	caKey       crypto.Signer
+	jwtKeyID    string
+	jwtKey      *ecdsa.PrivateKey
+}
+
+func newAuthority(trustDomain spiffeid.TrustDomain) (*authority, error) {
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	serial, err := newSerial()
+	if err != nil {
+		return nil, err
+	}
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{Organization: []string{"inprocess spire"}},
+		NotBefore:             time.Now().Add(-time.Minute),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		URIs:                  []*url.URL{trustDomain.ID().URL()},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, caKey.Public(), caKey)
+	if err != nil {
+		return nil, err
+	}
+	caCert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, err
+	}
+
+	jwtKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	keyID := make([]byte, 16)
+	if _, err := rand.Read(keyID); err != nil {
+		return nil, err
+	}
+
+	return &authority{
+		trustDomain: trustDomain,
+		caCert:      caCert,
+		caKey:       caKey,
+		jwtKeyID:    hex.EncodeToString(keyID),
+		jwtKey:      jwtKey,
+	}, nil
+}
+
+func (a *authority) x509Bundle() *x509bundle.Bundle {
+	return x509bundle.FromX509Authorities(a.trustDomain, []*x509.Certificate{a.caCert})
+}
+
+func (a *authority) jwtBundle() *jwtbundle.Bundle {
+	return jwtbundle.FromJWTAuthorities(a.trustDomain, map[string]crypto.PublicKey{
+		a.jwtKeyID: a.jwtKey.Public(),
+	})
+}
+
+// issueX509SVID issues a new X509-SVID with a new key for spiffeID
+func (a *authority) issueX509SVID(spiffeID spiffeid.ID, ttl time.Duration) (*workload.X509SVID, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	serial, err := newSerial()
+	if err != nil {
+		return nil, err
+	}
+	notAfter := time.Now().Add(ttl)
+	if notAfter.After(a.caCert.NotAfter) {
+		notAfter = a.caCert.NotAfter
+	}
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		NotBefore:    time.Now().Add(-time.Minute),
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment | x509.KeyUsageKeyAgreement,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		URIs:         []*url.URL{spiffeID.URL()},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, a.caCert, key.Public(), a.caKey)
+	if err != nil {
+		return nil, err
+	}
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	return &workload.X509SVID{
+		SpiffeId:    spiffeID.String(),
+		X509Svid:    der,
+		X509SvidKey: keyDER,
+		Bundle:      a.caCert.Raw,
+	}, nil
+}
+
+// signJWTSVID issues a new JWT-SVID for spiffeID with the audience
+func (a *authority) signJWTSVID(spiffeID spiffeid.ID, audience []string, ttl time.Duration) (string, error) {
+	if len(audience) == 0 {
+		return "", errors.New("audience must be specified")
+	}
+	now := time.Now()
+	tok := jwt.NewWithClaims(jwt.SigningMethodES256, jwt.MapClaims{
+		"sub": spiffeID.String(),
+		"aud": audience,
+		"iat": now.Unix(),
+		"exp": now.Add(ttl).Unix(),
+	})
+	tok.Header["kid"] = a.jwtKeyID
+	return tok.SignedString(a.jwtKey)
+}
+
+func newSerial() (*big.Int, error) {
+	return rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+}
@@ -0,0 +1,70 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inprocess
+
+import (
+	"time"
+)
+
+type entry struct {
+	spiffeID string
+	selector string
+}
+
+type config struct {
+	trustDomain string
+	svidTTL     time.Duration
+	entries     []*entry
+	setEnv      bool
+}
+
+// Option is an option pattern for Start
+type Option func(c *config)
+
+// WithTrustDomain sets the trust domain of the issued SVIDs. Default is "example.org".
+func WithTrustDomain(trustDomain string) Option {
+	return func(c *config) {
+		c.trustDomain = trustDomain
+	}
+}
+
+// WithSVIDTTL sets the lifetime of the issued SVIDs. Default is 1 hour.
+func WithSVIDTTL(ttl time.Duration) Option {
+	return func(c *config) {
+		c.svidTTL = ttl
+	}
+}
+
+// WithEntry adds registration entry issuing spiffeID to the workloads matching the selector. Supported selectors
+// are "unix:uid:<uid>", "unix:gid:<gid>" and "unix:pid:<pid>". May be used multiple times.
+func WithEntry(spiffeID, selector string) Option {
+	return func(c *config) {
+		c.entries = append(c.entries, &entry{
+			spiffeID: spiffeID,
+			selector: selector,
+		})
+	}
+}
+
+// WithSocketEnv sets workloadapi.SocketEnv to the server address, like spire.Start does, so the Workload API clients
+// find the server without Server.Addr. The previous value is restored when the server is stopped. The environment is
+// process wide, so the servers started with this option must not run concurrently.
+func WithSocketEnv() Option {
+	return func(c *config) {
+		c.setEnv = true
+	}
+}
@@ -0,0 +1,362 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inprocess
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/jsonpb"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	"github.com/pkg/errors"
+	"github.com/spiffe/go-spiffe/v2/bundle/jwtbundle"
+	"github.com/spiffe/go-spiffe/v2/bundle/x509bundle"
+	"github.com/spiffe/go-spiffe/v2/proto/spiffe/workload"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/go-spiffe/v2/svid/jwtsvid"
+	"github.com/spiffe/go-spiffe/v2/workloadapi"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// Server is an in-process Workload API server
+type Server struct {
+	addr      string
+	svidTTL   time.Duration
+	authority *authority
+
+	mutex   sync.Mutex
+	entries []*entry
+	svids   map[string]*workload.X509SVID
+	// updated is closed and replaced on every change of the issued X509-SVIDs
+	updated chan struct{}
+}
+
+// Start starts the Workload API server on a unix socket in a temporary directory, the server is stopped and the
+// directory is removed when ctx is done. Server.Addr should be passed to the Workload API clients, unless
+// WithSocketEnv is used.
+func Start(ctx context.Context, options ...Option) (*Server, error) {
+	c := &config{
+		trustDomain: "example.org",
+		svidTTL:     time.Hour,
+	}
+	for _, o := range options {
+		o(c)
+	}
+
+	trustDomain, err := spiffeid.TrustDomainFromString(c.trustDomain)
+	if err != nil {
+		return nil, err
+	}
+	a, err := newAuthority(trustDomain)
+	if err != nil {
+		return nil, err
+	}
+	s := &Server{
+		svidTTL:   c.svidTTL,
+		authority: a,
+		svids:     make(map[string]*workload.X509SVID),
+		updated:   make(chan struct{}),
+	}
+	for _, e := range c.entries {
+		if err := s.AddEntry(e.spiffeID, e.selector); err != nil {
+			return nil, err
+		}
+	}
+
+	dir, err := ioutil.TempDir("", "inprocess-spire")
+	if err != nil {
+		return nil, err
+	}
+	socketPath := filepath.Join(dir, "agent.sock")
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		_ = os.RemoveAll(dir)
+		return nil, err
+	}
+	s.addr = "unix:" + socketPath
+	restoreEnv := func() {}
+	if c.setEnv {
+		prevEnv, hadEnv := os.LookupEnv(workloadapi.SocketEnv)
+		if err := os.Setenv(workloadapi.SocketEnv, s.addr); err != nil {
+			_ = listener.Close()
+			_ = os.RemoveAll(dir)
+			return nil, err
+		}
+		restoreEnv = func() {
+			if hadEnv {
+				_ = os.Setenv(workloadapi.SocketEnv, prevEnv)
+				return
+			}
+			_ = os.Unsetenv(workloadapi.SocketEnv)
+		}
+	}
+
+	grpcServer := grpc.NewServer(grpc.Creds(&peerCredentials{}))
+	workload.RegisterSpiffeWorkloadAPIServer(grpcServer, s)
+	go func() {
+		if err := grpcServer.Serve(listener); err != nil {
+			log.Entry(ctx).Errorf("inprocess spire: %v", err)
+		}
+	}()
+	go func() {
+		<-ctx.Done()
+		grpcServer.Stop()
+		restoreEnv()
+		_ = os.RemoveAll(dir)
+	}()
+	return s, nil
+}
+
+// Addr returns the Workload API address of the server, e.g. for workloadapi.WithAddr
+func (s *Server) Addr() string {
+	return s.addr
+}
+
+// X509Bundle returns X.509 bundle of the trust domain
+func (s *Server) X509Bundle() *x509bundle.Bundle {
+	return s.authority.x509Bundle()
+}
+
+// JWTBundle returns JWT bundle of the trust domain
+func (s *Server) JWTBundle() *jwtbundle.Bundle {
+	return s.authority.jwtBundle()
+}
+
+// AddEntry adds registration entry issuing spiffeID to the workloads matching the selector, the watching workloads
+// are updated
+func (s *Server) AddEntry(spiffeID, selector string) error {
+	id, err := spiffeid.FromString(spiffeID)
+	if err != nil {
+		return err
+	}
+	if !id.MemberOf(s.authority.trustDomain) {
+		return errors.Errorf("%v is not a member of trust domain %v", spiffeID, s.authority.trustDomain)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, ok := s.svids[id.String()]; !ok {
+		svid, err := s.authority.issueX509SVID(id, s.svidTTL)
+		if err != nil {
+			return err
+		}
+		s.svids[id.String()] = svid
+	}
+	s.entries = append(s.entries, &entry{
+		spiffeID: id.String(),
+		selector: selector,
+	})
+	s.notify()
+	return nil
+}
+
+// Rotate reissues all X509-SVIDs with new keys, the watching workloads are updated
+func (s *Server) Rotate() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	svids := make(map[string]*workload.X509SVID, len(s.svids))
+	for spiffeID := range s.svids {
+		id, err := spiffeid.FromString(spiffeID)
+		if err != nil {
+			return err
+		}
+		if svids[spiffeID], err = s.authority.issueX509SVID(id, s.svidTTL); err != nil {
+			return err
+		}
+	}
+	s.svids = svids
+	s.notify()
+	return nil
+}
+
+// notify wakes up the X509-SVID watchers, s.mutex should be locked
+func (s *Server) notify() {
+	close(s.updated)
+	s.updated = make(chan struct{})
+}
+
+// x509Response returns the X509-SVIDs of the caller and the channel closed on their update
+func (s *Server) x509Response(caller *callerInfo) (*workload.X509SVIDResponse, <-chan struct{}) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	resp := &workload.X509SVIDResponse{}
+	for _, spiffeID := range s.spiffeIDs(caller, "") {
+		resp.Svids = append(resp.Svids, s.svids[spiffeID])
+	}
+	return resp, s.updated
+}
+
+// spiffeIDs returns SPIFFE IDs issued to the caller filtered by spiffeID if set, s.mutex should be locked
+func (s *Server) spiffeIDs(caller *callerInfo, spiffeID string) []string {
+	selectors := make(map[string]bool)
+	for _, selector := range caller.selectors() {
+		selectors[selector] = true
+	}
+	var ids []string
+	found := make(map[string]bool)
+	for _, e := range s.entries {
+		if !selectors[e.selector] || found[e.spiffeID] || (spiffeID != "" && spiffeID != e.spiffeID) {
+			continue
+		}
+		found[e.spiffeID] = true
+		ids = append(ids, e.spiffeID)
+	}
+	return ids
+}
+
+// FetchX509SVID sends X509-SVIDs of the caller and their updates
+func (s *Server) FetchX509SVID(_ *workload.X509SVIDRequest, stream workload.SpiffeWorkloadAPI_FetchX509SVIDServer) error {
+	caller, err := authenticate(stream.Context())
+	if err != nil {
+		return err
+	}
+	for {
+		resp, updated := s.x509Response(caller)
+		if len(resp.Svids) == 0 {
+			return status.Error(codes.PermissionDenied, "no identity issued")
+		}
+		if err := stream.Send(resp); err != nil {
+			return err
+		}
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case <-updated:
+		}
+	}
+}
+
+// FetchJWTSVID issues JWT-SVIDs of the caller
+func (s *Server) FetchJWTSVID(ctx context.Context, req *workload.JWTSVIDRequest) (*workload.JWTSVIDResponse, error) {
+	caller, err := authenticate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(req.Audience) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "audience must be specified")
+	}
+
+	s.mutex.Lock()
+	spiffeIDs := s.spiffeIDs(caller, req.SpiffeId)
+	s.mutex.Unlock()
+	if len(spiffeIDs) == 0 {
+		return nil, status.Error(codes.PermissionDenied, "no identity issued")
+	}
+
+	resp := &workload.JWTSVIDResponse{}
+	for _, spiffeID := range spiffeIDs {
+		id, err := spiffeid.FromString(spiffeID)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		token, err := s.authority.signJWTSVID(id, req.Audience, s.svidTTL)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		resp.Svids = append(resp.Svids, &workload.JWTSVID{
+			SpiffeId: spiffeID,
+			Svid:     token,
+		})
+	}
+	return resp, nil
+}
+
+// FetchJWTBundles sends JWT bundle of the trust domain
+func (s *Server) FetchJWTBundles(_ *workload.JWTBundlesRequest, stream workload.SpiffeWorkloadAPI_FetchJWTBundlesServer) error {
+	if _, err := authenticate(stream.Context()); err != nil {
+		return err
+	}
+	bundle, err := s.JWTBundle().Marshal()
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	if err := stream.Send(&workload.JWTBundlesResponse{
+		Bundles: map[string][]byte{s.authority.trustDomain.IDString(): bundle},
+	}); err != nil {
+		return err
+	}
+	// JWT signing key is never rotated, so there are no updates
+	<-stream.Context().Done()
+	return nil
+}
+
+// ValidateJWTSVID validates JWT-SVID against JWT bundle of the trust domain
+func (s *Server) ValidateJWTSVID(ctx context.Context, req *workload.ValidateJWTSVIDRequest) (*workload.ValidateJWTSVIDResponse, error) {
+	if _, err := authenticate(ctx); err != nil {
+		return nil, err
+	}
+	if req.Audience == "" {
+		return nil, status.Error(codes.InvalidArgument, "audience must be specified")
+	}
+	if req.Svid == "" {
+		return nil, status.Error(codes.InvalidArgument, "svid must be specified")
+	}
+	svid, err := jwtsvid.ParseAndValidate(req.Svid, s.JWTBundle(), []string{req.Audience})
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	claims, err := structFromValues(svid.Claims)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &workload.ValidateJWTSVIDResponse{
+		SpiffeId: svid.ID.String(),
+		Claims:   claims,
+	}, nil
+}
+
+// authenticate checks the Workload API security header and returns the attested caller
+func authenticate(ctx context.Context) (*callerInfo, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok || len(md.Get("workload.spiffe.io")) == 0 || md.Get("workload.spiffe.io")[0] != "true" {
+		return nil, status.Error(codes.InvalidArgument, "security header missing from request")
+	}
+	caller, err := callerFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	return caller, nil
+}
+
+func structFromValues(values map[string]interface{}) (*structpb.Struct, error) {
+	valuesJSON, err := json.Marshal(values)
+	if err != nil {
+		return nil, err
+	}
+	s := new(structpb.Struct)
+	if err := jsonpb.Unmarshal(bytes.NewReader(valuesJSON), s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+var _ workload.SpiffeWorkloadAPIServer = &Server{}
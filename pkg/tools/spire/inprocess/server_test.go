@@ -0,0 +1,181 @@
+// Copyright (c) 2020 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inprocess_test
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/spiffe/go-spiffe/v2/spiffetls/tlsconfig"
+	"github.com/spiffe/go-spiffe/v2/svid/jwtsvid"
+	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
+	"github.com/spiffe/go-spiffe/v2/workloadapi"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/opa"
+	"github.com/networkservicemesh/sdk/pkg/tools/spiffejwt"
+	"github.com/networkservicemesh/sdk/pkg/tools/spire/inprocess"
+)
+
+var uidSelector = fmt.Sprintf("unix:uid:%d", os.Getuid())
+
+func TestServer_X509SVID(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	s, err := inprocess.Start(ctx,
+		inprocess.WithEntry("spiffe://example.org/nsc", uidSelector),
+		inprocess.WithEntry("spiffe://example.org/other", "unix:uid:123456789"),
+	)
+	require.NoError(t, err)
+
+	source, err := workloadapi.NewX509Source(ctx, workloadapi.WithClientOptions(workloadapi.WithAddr(s.Addr())))
+	require.NoError(t, err)
+	defer func() { _ = source.Close() }()
+
+	svid, err := source.GetX509SVID()
+	require.NoError(t, err)
+	require.Equal(t, "spiffe://example.org/nsc", svid.ID.String())
+	_, _, err = x509svid.Verify(svid.Certificates, s.X509Bundle())
+	require.NoError(t, err)
+
+	require.NoError(t, s.Rotate())
+	require.Eventually(t, func() bool {
+		rotated, err := source.GetX509SVID()
+		return err == nil && rotated.Certificates[0].SerialNumber.Cmp(svid.Certificates[0].SerialNumber) != 0
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestServer_NoIdentity(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	s, err := inprocess.Start(ctx, inprocess.WithEntry("spiffe://example.org/other", "unix:uid:123456789"))
+	require.NoError(t, err)
+
+	client, err := workloadapi.New(ctx, workloadapi.WithAddr(s.Addr()))
+	require.NoError(t, err)
+	defer func() { _ = client.Close() }()
+
+	_, err = client.FetchJWTSVID(ctx, jwtsvid.Params{Audience: "nsmgr"})
+	require.Error(t, err)
+}
+
+func TestServer_JWTSVID(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	s, err := inprocess.Start(ctx, inprocess.WithEntry("spiffe://example.org/nsc", uidSelector))
+	require.NoError(t, err)
+
+	client, err := workloadapi.New(ctx, workloadapi.WithAddr(s.Addr()))
+	require.NoError(t, err)
+	defer func() { _ = client.Close() }()
+
+	svid, err := client.FetchJWTSVID(ctx, jwtsvid.Params{Audience: "nsmgr"})
+	require.NoError(t, err)
+	require.Equal(t, "spiffe://example.org/nsc", svid.ID.String())
+
+	bundles, err := client.FetchJWTBundles(ctx)
+	require.NoError(t, err)
+	_, err = jwtsvid.ParseAndValidate(svid.Marshal(), bundles, []string{"nsmgr"})
+	require.NoError(t, err)
+
+	validated, err := client.ValidateJWTSVID(ctx, svid.Marshal(), "nsmgr")
+	require.NoError(t, err)
+	require.Equal(t, svid.ID, validated.ID)
+
+	_, err = client.ValidateJWTSVID(ctx, svid.Marshal(), "nse")
+	require.Error(t, err)
+}
+
+func TestServer_MTLS(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	s, err := inprocess.Start(ctx, inprocess.WithEntry("spiffe://example.org/nsmgr", uidSelector))
+	require.NoError(t, err)
+
+	source, err := workloadapi.NewX509Source(ctx, workloadapi.WithClientOptions(workloadapi.WithAddr(s.Addr())))
+	require.NoError(t, err)
+	defer func() { _ = source.Close() }()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpc.NewServer(grpc.Creds(credentials.NewTLS(tlsconfig.MTLSServerConfig(source, source, tlsconfig.AuthorizeAny()))))
+	grpc_health_v1.RegisterHealthServer(server, health.NewServer())
+	go func() { _ = server.Serve(listener) }()
+	defer server.Stop()
+
+	cc, err := grpc.DialContext(ctx, listener.Addr().String(),
+		grpc.WithTransportCredentials(credentials.NewTLS(tlsconfig.MTLSClientConfig(source, source, tlsconfig.AuthorizeMemberOf(s.X509Bundle().TrustDomain())))),
+		grpc.WithBlock(),
+	)
+	require.NoError(t, err)
+	defer func() { _ = cc.Close() }()
+
+	_, err = grpc_health_v1.NewHealthClient(cc).Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+	require.NoError(t, err)
+
+	// spiffejwt tokens signed by the issued SVID are verified against the issued trust bundle
+	token, _, err := spiffejwt.TokenGeneratorFunc(source, time.Hour)(nil)
+	require.NoError(t, err)
+	path := &networkservice.Path{PathSegments: []*networkservice.PathSegment{{Token: token}}}
+	require.NoError(t, opa.WithTokensSignedPolicy().Check(opa.WithTrustBundle(ctx, source), path))
+}
+
+func TestServer_SocketEnv(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	prevEnv, hadEnv := os.LookupEnv(workloadapi.SocketEnv)
+	defer func() {
+		if hadEnv {
+			_ = os.Setenv(workloadapi.SocketEnv, prevEnv)
+			return
+		}
+		_ = os.Unsetenv(workloadapi.SocketEnv)
+	}()
+	require.NoError(t, os.Setenv(workloadapi.SocketEnv, "unix:/previous.sock"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	_, err := inprocess.Start(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "unix:/previous.sock", os.Getenv(workloadapi.SocketEnv))
+	cancel()
+
+	ctx, cancel = context.WithCancel(context.Background())
+	s, err := inprocess.Start(ctx, inprocess.WithSocketEnv())
+	require.NoError(t, err)
+	require.Equal(t, s.Addr(), os.Getenv(workloadapi.SocketEnv))
+	cancel()
+	require.Eventually(t, func() bool {
+		return os.Getenv(workloadapi.SocketEnv) == "unix:/previous.sock"
+	}, time.Second, 10*time.Millisecond)
+}
@@ -30,3 +30,15 @@ func GetFuncName(value interface{}, methodName string) string {
 	}
 	return fmt.Sprintf("%s/%s.%s", v.Type().PkgPath(), v.Type().Name(), methodName)
 }
+
+// GetTypeName - returns the package qualified type name of the passed value (interface), e.g. "trace.traceServer"
+func GetTypeName(value interface{}) string {
+	t := reflect.TypeOf(value)
+	if t == nil {
+		return ""
+	}
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.String()
+}